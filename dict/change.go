@@ -0,0 +1,119 @@
+package dict
+
+import (
+	"fmt"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/util"
+)
+
+// A single difference between two dictionaries, as produced by Changes.
+type Change[A nub.Ord, B any] interface {
+	Key() A
+	String() string
+	apply(Dict[A, B]) Dict[A, B]
+}
+
+type added[A nub.Ord, B any] struct {
+	key   A
+	value B
+}
+
+type removed[A nub.Ord, B any] struct {
+	key   A
+	value B
+}
+
+type changed[A nub.Ord, B any] struct {
+	key    A
+	before B
+	after  B
+}
+
+// A key that only appears in the new dictionary.
+func Added[A nub.Ord, B any](key A, value B) Change[A, B] {
+	return added[A, B]{key: key, value: value}
+}
+
+// A key that only appears in the old dictionary.
+func Removed[A nub.Ord, B any](key A, value B) Change[A, B] {
+	return removed[A, B]{key: key, value: value}
+}
+
+// A key whose value differs between the old and the new dictionary.
+func Changed[A nub.Ord, B any](key A, before B, after B) Change[A, B] {
+	return changed[A, B]{key: key, before: before, after: after}
+}
+
+func (c added[A, B]) Key() A {
+	return c.key
+}
+
+func (c removed[A, B]) Key() A {
+	return c.key
+}
+
+func (c changed[A, B]) Key() A {
+	return c.key
+}
+
+func (c added[A, B]) String() string {
+	return fmt.Sprintf("Added(%s, %s)", util.Stringify(c.key), util.Stringify(c.value))
+}
+
+func (c removed[A, B]) String() string {
+	return fmt.Sprintf("Removed(%s, %s)", util.Stringify(c.key), util.Stringify(c.value))
+}
+
+func (c changed[A, B]) String() string {
+	return fmt.Sprintf("Changed(%s, %s, %s)", util.Stringify(c.key), util.Stringify(c.before), util.Stringify(c.after))
+}
+
+func (c added[A, B]) apply(dict Dict[A, B]) Dict[A, B] {
+	return Insert(c.key, c.value, dict)
+}
+
+func (c removed[A, B]) apply(dict Dict[A, B]) Dict[A, B] {
+	return Remove(c.key, dict)
+}
+
+func (c changed[A, B]) apply(dict Dict[A, B]) Dict[A, B] {
+	return Insert(c.key, c.after, dict)
+}
+
+// Handle each kind of change with its own function.
+func MatchChange[A nub.Ord, B, C any](change Change[A, B], onAdded func(A, B) C, onRemoved func(A, B) C, onChanged func(A, B, B) C) C {
+	switch c := change.(type) {
+	case added[A, B]:
+		return onAdded(c.key, c.value)
+	case removed[A, B]:
+		return onRemoved(c.key, c.value)
+	}
+	c := change.(changed[A, B])
+	return onChanged(c.key, c.before, c.after)
+}
+
+// List the changes that turn the old dictionary into the new one, sorted by
+// key. Values under keys found in both are compared with the given equality,
+// and only unequal ones are reported as Changed.
+func Changes[A nub.Ord, B any](eq func(B, B) bool, before Dict[A, B], after Dict[A, B]) list.List[Change[A, B]] {
+	return list.Reverse[Change[A, B]](Merge(func(key A, value B, acc list.List[Change[A, B]]) list.List[Change[A, B]] {
+		return list.Cons(Removed(key, value), acc)
+	}, func(key A, was B, now B, acc list.List[Change[A, B]]) list.List[Change[A, B]] {
+		if eq(was, now) {
+			return acc
+		}
+		return list.Cons(Changed(key, was, now), acc)
+	}, func(key A, value B, acc list.List[Change[A, B]]) list.List[Change[A, B]] {
+		return list.Cons(Added(key, value), acc)
+	}, before, after, list.Nil[Change[A, B]]()))
+}
+
+// Apply a list of changes to a dictionary, in order. Added and Changed set the
+// key to the new value, and Removed removes the key.
+func Patch[A nub.Ord, B any](changes list.List[Change[A, B]], dict Dict[A, B]) Dict[A, B] {
+	return list.FoldL(func(change Change[A, B], acc Dict[A, B]) Dict[A, B] {
+		return change.apply(acc)
+	}, dict, changes)
+}
@@ -0,0 +1,54 @@
+package dict
+
+import (
+	"testing"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+)
+
+func TestChanges(t *testing.T) {
+	eq := func(a string, b string) bool { return a == b }
+	before := FromGoMap(map[string]string{"db": "v1", "cache": "v1", "queue": "v1"})
+	after := FromGoMap(map[string]string{"db": "v2", "cache": "v1", "search": "v1"})
+
+	changes := Changes(eq, before, after)
+	expected := list.FromSlice([]Change[string, string]{
+		Changed("db", "v1", "v2"),
+		Removed("queue", "v1"),
+		Added("search", "v1"),
+	})
+	if changes != expected {
+		t.Errorf("Changes are sorted by key, got %v", changes)
+	}
+
+	if !Equal(Patch(changes, before), after) {
+		t.Error("Patch applies the changes")
+	}
+
+	if !list.IsEmpty[Change[string, string]](Changes(eq, before, before)) {
+		t.Error("no changes between equal dictionaries")
+	}
+
+	sameLength := func(a string, b string) bool { return len(a) == len(b) }
+	if list.Length[Change[string, string]](Changes(sameLength, before, after)) != 2 {
+		t.Error("Changes uses the given equality")
+	}
+
+	restarts := list.FilterMap(func(c Change[string, string]) maybe.Maybe[string] {
+		return MatchChange(c, func(string, string) maybe.Maybe[string] {
+			return maybe.Nothing[string]()
+		}, func(k string, _ string) maybe.Maybe[string] {
+			return maybe.Just("stop " + k)
+		}, func(k string, _ string, _ string) maybe.Maybe[string] {
+			return maybe.Just("restart " + k)
+		})
+	}, changes)
+	if restarts != list.FromSlice([]string{"restart db", "stop queue"}) {
+		t.Errorf("MatchChange, got %v", restarts)
+	}
+
+	if changes.String() != `[Changed("db", "v1", "v2"), Removed("queue", "v1"), Added("search", "v1")]` {
+		t.Errorf("String, got %s", changes)
+	}
+}
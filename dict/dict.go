@@ -1,6 +1,13 @@
 package dict
 
 import (
+	"bytes"
+	"encoding/json"
+	"iter"
+	"reflect"
+
+	"github.com/obiloud/curry-go/either"
+	"github.com/obiloud/curry-go/internal/rbtree"
 	"github.com/obiloud/curry-go/list"
 	"github.com/obiloud/curry-go/maybe"
 	"github.com/obiloud/curry-go/nub"
@@ -8,59 +15,85 @@ import (
 )
 
 // A dictionary mapping unique keys to values. The keys can be any comparable type.
+//
+// Dictionaries are persistent red-black trees, so Insert, Get, Remove and
+// Update run in O(log n) and share structure with the dictionary they were
+// derived from.
+//
+// Two dictionaries with the same contents can have differently shaped trees,
+// so dictionaries cannot be compared with `==`. Use Equal or EqualWith instead.
 type Dict[A nub.Ord, B any] struct {
-	dict list.List[tuple.Tuple[A, B]]
+	// Makes `==` a compile error instead of a comparison of tree shapes.
+	_    [0]func()
+	root rbtree.Tree[A, B]
 }
 
 // Convert a dictionary into a string.
 func (dict Dict[A, B]) String() string {
-	return dict.dict.String()
+	return ToList(dict).String()
 }
 
 // Create an empty dictionary.
 func Empty[A nub.Ord, B any]() Dict[A, B] {
-	return Dict[A, B]{dict: list.Nil[tuple.Tuple[A, B]]()}
+	return Dict[A, B]{root: rbtree.Leaf[A, B]{}}
 }
 
 // Determine if a dictionary is empty.
 func IsEmpty[A nub.Ord, B any](dict Dict[A, B]) bool {
-	return list.IsEmpty[A](dict.dict)
+	return !dict.root.IsNode()
 }
 
 // Create a dictionary with one key-value pair.
 func Singleton[A nub.Ord, B any](key A, value B) Dict[A, B] {
-	return Dict[A, B]{dict: list.Singleton(tuple.Pair(key, value))}
+	return Dict[A, B]{root: rbtree.Singleton(key, value)}
 }
 
 // Get the value associated with a key. If the key is not found, return
 // `Nothing`. This is useful when you are not sure if a key will be in the
 // dictionary.
 func Get[A nub.Ord, B any](key A, dict Dict[A, B]) maybe.Maybe[B] {
-	return maybe.Map(tuple.Second[A, B],
-		list.Head[tuple.Tuple[A, B]](list.Filter(func(pair tuple.Tuple[A, B]) bool {
-			return tuple.First(pair) == key
-		}, dict.dict)),
-	)
+	if value, ok := rbtree.Get[A, B](nub.Compare[A], key, dict.root); ok {
+		return maybe.Just(value)
+	}
+	return maybe.Nothing[B]()
 }
 
 // Determine if a key is in a dictionary.
 func Member[A nub.Ord, B any](key A, dict Dict[A, B]) bool {
-	return list.Member(key, Keys(dict))
+	return Get(key, dict).IsJust()
+}
+
+// Determine if two dictionaries hold the same keys with the same values.
+func Equal[A nub.Ord, B comparable](a Dict[A, B], b Dict[A, B]) bool {
+	return EqualWith(func(x B, y B) bool {
+		return x == y
+	}, a, b)
+}
+
+// Determine if two dictionaries hold the same keys, with values that are
+// equal according to the given function.
+func EqualWith[A nub.Ord, B any](eq func(B, B) bool, a Dict[A, B], b Dict[A, B]) bool {
+	return rbtree.Equal(nub.Compare[A], eq, a.root, b.root)
 }
 
 // Determine the number of key-value pairs in the dictionary.
 func Size[A nub.Ord, B any](dict Dict[A, B]) int {
-	return list.Length[tuple.Tuple[A, B]](dict.dict)
+	return rbtree.Size[A, B](dict.root)
 }
 
-// Insert a key-value pair into a dictionary. Replaces value when there is a collision.
+// Insert a key-value pair into a dictionary. Replaces value when there is a
+// collision, and keeps the key that was already stored.
 func Insert[A nub.Ord, B any](key A, value B, dict Dict[A, B]) Dict[A, B] {
-	return FoldR(func(k A, v B, acc Dict[A, B]) Dict[A, B] {
-		if !list.Member(k, Keys(acc)) {
-			return Dict[A, B]{dict: list.SortBy(tuple.First[A, B], list.Cons(tuple.Pair(k, v), acc.dict))}
-		}
-		return acc
-	}, Singleton(key, value), dict)
+	return Dict[A, B]{root: rbtree.Insert(nub.Compare[A], key, value, dict.root)}
+}
+
+// Insert a key-value pair into a dictionary. When the key is already present,
+// the stored value becomes `combine(old, value)`.
+func InsertWith[A nub.Ord, B any](combine func(B, B) B, key A, value B, dict Dict[A, B]) Dict[A, B] {
+	if old, ok := rbtree.Get[A, B](nub.Compare[A], key, dict.root); ok {
+		return Insert(key, combine(old, value), dict)
+	}
+	return Insert(key, value, dict)
 }
 
 // Update the value of a dictionary for a specific key with a given function.
@@ -73,16 +106,130 @@ func Update[A nub.Ord, B any](key A, updateFunc func(maybe.Maybe[B]) maybe.Maybe
 	)
 }
 
+// Like Update, but also returns the value stored at the key before the update.
+func Alter[A nub.Ord, B any](key A, updateFunc func(maybe.Maybe[B]) maybe.Maybe[B], dict Dict[A, B]) tuple.Tuple[maybe.Maybe[B], Dict[A, B]] {
+	old := Get(key, dict)
+	if value, ok := maybe.Get[B](updateFunc(old)); ok {
+		return tuple.Pair(old, Insert(key, value, dict))
+	}
+	return tuple.Pair(old, Remove(key, dict))
+}
+
 // Remove a key-value pair from a dictionary. If the key is not found, no changes are made.
 func Remove[A nub.Ord, B any](key A, dict Dict[A, B]) Dict[A, B] {
-	return Dict[A, B]{dict: list.Filter(func(pair tuple.Tuple[A, B]) bool { return tuple.First(pair) != key }, dict.dict)}
+	return Dict[A, B]{root: rbtree.Remove[A, B](nub.Compare[A], key, dict.root)}
+}
+
+// ORDER
+
+// Get the key-value pair with the lowest key.
+func Min[A nub.Ord, B any](dict Dict[A, B]) maybe.Maybe[tuple.Tuple[A, B]] {
+	return nodePair(rbtree.Min[A, B](dict.root))
+}
+
+// Get the key-value pair with the highest key.
+func Max[A nub.Ord, B any](dict Dict[A, B]) maybe.Maybe[tuple.Tuple[A, B]] {
+	return nodePair(rbtree.Max[A, B](dict.root))
+}
+
+// Get the key-value pair with the greatest key that is less than or equal to
+// the given key.
+func Floor[A nub.Ord, B any](key A, dict Dict[A, B]) maybe.Maybe[tuple.Tuple[A, B]] {
+	return nodePair(rbtree.Floor[A, B](nub.Compare[A], key, dict.root))
+}
+
+// Get the key-value pair with the least key that is greater than or equal to
+// the given key.
+func Ceiling[A nub.Ord, B any](key A, dict Dict[A, B]) maybe.Maybe[tuple.Tuple[A, B]] {
+	return nodePair(rbtree.Ceiling[A, B](nub.Compare[A], key, dict.root))
+}
+
+func nodePair[A nub.Ord, B any](n rbtree.Node[A, B], ok bool) maybe.Maybe[tuple.Tuple[A, B]] {
+	if !ok {
+		return maybe.Nothing[tuple.Tuple[A, B]]()
+	}
+	return maybe.Just(tuple.Pair(n.Key, n.Value))
+}
+
+// Keep the key-value pairs with keys between lo and hi, inclusive. Only the
+// part of the dictionary inside the range is visited.
+func Range[A nub.Ord, B any](lo A, hi A, dict Dict[A, B]) Dict[A, B] {
+	return rbtree.FoldLBetween(func(key A) bool {
+		return key >= lo
+	}, func(key A) bool {
+		return key <= hi
+	}, Insert[A, B], Empty[A, B](), dict.root)
+}
+
+// Split a dictionary at a key. Returns the pairs with lower keys, the value
+// stored at the key if any, and the pairs with higher keys.
+func Split[A nub.Ord, B any](key A, dict Dict[A, B]) tuple.Triple[Dict[A, B], maybe.Maybe[B], Dict[A, B]] {
+	always := nub.Const[bool, A](true)
+	lower := rbtree.FoldLBetween(always, func(k A) bool {
+		return k < key
+	}, Insert[A, B], Empty[A, B](), dict.root)
+	upper := rbtree.FoldLBetween(func(k A) bool {
+		return k > key
+	}, always, Insert[A, B], Empty[A, B](), dict.root)
+	return tuple.Trio(lower, Get(key, dict), upper)
+}
+
+// Keep the n pairs with the lowest keys.
+func TakeSmallest[A nub.Ord, B any](n int, dict Dict[A, B]) Dict[A, B] {
+	result := Empty[A, B]()
+	if n <= 0 {
+		return result
+	}
+	for k, v := range All(dict) {
+		result = Insert(k, v, result)
+		if n--; n == 0 {
+			break
+		}
+	}
+	return result
+}
+
+// Drop pairs from the lowest key upwards for as long as their keys pass the
+// given test.
+func DropWhileKey[A nub.Ord, B any](isDropped func(A) bool, dict Dict[A, B]) Dict[A, B] {
+	for k := range All(dict) {
+		if !isDropped(k) {
+			return rbtree.FoldLBetween(func(key A) bool {
+				return key >= k
+			}, nub.Const[bool, A](true), Insert[A, B], Empty[A, B](), dict.root)
+		}
+	}
+	return Empty[A, B]()
+}
+
+// Fold over the key-value pairs with keys less than or equal to the given key,
+// from that key down to the lowest.
+func FoldRFrom[A nub.Ord, B any, C any](fn func(A, B, C) C, acc C, key A, dict Dict[A, B]) C {
+	return rbtree.FoldRBetween(nub.Const[bool, A](true), func(k A) bool {
+		return k <= key
+	}, fn, acc, dict.root)
 }
 
 // COMBINE
 
 // Combine two dictionaries. If there is a collision, preference is given to the first dictionary.
 func Union[A nub.Ord, B any](a Dict[A, B], b Dict[A, B]) Dict[A, B] {
-	return FoldR(Insert[A, B], a, b)
+	return FoldL(Insert[A, B], b, a)
+}
+
+// Combine two dictionaries. If there is a collision, the value becomes
+// `combine(a value, b value)`.
+func UnionWith[A nub.Ord, B any](combine func(B, B) B, a Dict[A, B], b Dict[A, B]) Dict[A, B] {
+	return FoldL(func(key A, value B, acc Dict[A, B]) Dict[A, B] {
+		return InsertWith(combine, key, value, acc)
+	}, a, b)
+}
+
+// Combine a list of dictionaries from first to last with UnionWith.
+func UnionsWith[A nub.Ord, B any](combine func(B, B) B, dicts list.List[Dict[A, B]]) Dict[A, B] {
+	return list.FoldL(func(dict Dict[A, B], acc Dict[A, B]) Dict[A, B] {
+		return UnionWith(combine, acc, dict)
+	}, Empty[A, B](), dicts)
 }
 
 // Keep a key-value pair when its key appears in the second dictionary. Preference is given to values in the first dictionary.
@@ -92,6 +239,17 @@ func Intersect[A nub.Ord, B any](a Dict[A, B], b Dict[A, B]) Dict[A, B] {
 	}, a)
 }
 
+// Keep the keys that appear in both dictionaries, combining their values.
+func IntersectWith[A nub.Ord, B, C, D any](combine func(B, C) D, a Dict[A, B], b Dict[A, C]) Dict[A, D] {
+	return Merge(func(_ A, _ B, acc Dict[A, D]) Dict[A, D] {
+		return acc
+	}, func(key A, left B, right C, acc Dict[A, D]) Dict[A, D] {
+		return Insert(key, combine(left, right), acc)
+	}, func(_ A, _ C, acc Dict[A, D]) Dict[A, D] {
+		return acc
+	}, a, b, Empty[A, D]())
+}
+
 // Keep a key-value pair when its key does not appear in the second dictionary.
 func Diff[A nub.Ord, B any](a Dict[A, B], b Dict[A, B]) Dict[A, B] {
 	return FoldR(func(key A, _ B, acc Dict[A, B]) Dict[A, B] {
@@ -108,69 +266,125 @@ func Diff[A nub.Ord, B any](a Dict[A, B], b Dict[A, B]) Dict[A, B] {
 // You then traverse all the keys from lowest to highest, building up whatever
 // you want.
 func Merge[A nub.Ord, B, C, D any](insertLeft func(A, B, D) D, insertBoth func(A, B, C, D) D, insertRight func(A, C, D) D, left Dict[A, B], right Dict[A, C], result D) D {
-	var step func(rKey A, rVal C, acc tuple.Tuple[list.List[tuple.Tuple[A, B]], D]) tuple.Tuple[list.List[tuple.Tuple[A, B]], D]
+	return rbtree.Merge(nub.Compare[A], insertLeft, insertBoth, insertRight, left.root, right.root, result)
+}
+
+// TRANSFORM
 
-	step = func(rKey A, rVal C, acc tuple.Tuple[list.List[tuple.Tuple[A, B]], D]) tuple.Tuple[list.List[tuple.Tuple[A, B]], D] {
-		xs := tuple.First(acc)
+// Apply a function to all values in a dictionary.
+func Map[A nub.Ord, B, C any](fn func(B) C, dict Dict[A, B]) Dict[A, C] {
+	return Dict[A, C]{root: rbtree.Map[A, B](func(_ A, value B) C {
+		return fn(value)
+	}, dict.root)}
+}
 
-		if list.IsEmpty[tuple.Tuple[A, B]](xs) {
-			return tuple.MapSecond(func(r D) D {
-				return insertRight(rKey, rVal, r)
-			}, acc)
+// Apply a function to all keys and values in a dictionary.
+func MapWithKey[A nub.Ord, B, C any](fn func(A, B) C, dict Dict[A, B]) Dict[A, C] {
+	return Dict[A, C]{root: rbtree.Map[A, B](fn, dict.root)}
+}
+
+// Apply a function that may fail to all key-value pairs, keeping the `Just`
+// results.
+func FilterMap[A nub.Ord, B, C any](fn func(A, B) maybe.Maybe[C], dict Dict[A, B]) Dict[A, C] {
+	return FoldL(func(key A, value B, acc Dict[A, C]) Dict[A, C] {
+		if c, ok := maybe.Get[C](fn(key, value)); ok {
+			return Insert(key, c, acc)
 		}
+		return acc
+	}, Empty[A, C](), dict)
+}
 
-		return maybe.WithDefault(
-			acc,
-			maybe.Map2(func(head tuple.Tuple[A, B], tail list.List[tuple.Tuple[A, B]]) tuple.Tuple[list.List[tuple.Tuple[A, B]], D] {
-				lKey := tuple.First(head)
-				lValue := tuple.Second(head)
+// Apply a function to all keys in a dictionary. When several keys map to the
+// same new key, the value of the highest original key wins.
+func MapKeys[A nub.Ord, B any, C nub.Ord](fn func(A) C, dict Dict[A, B]) Dict[C, B] {
+	return FoldL(func(key A, value B, acc Dict[C, B]) Dict[C, B] {
+		return Insert(fn(key), value, acc)
+	}, Empty[C, B](), dict)
+}
 
-				if lKey < rKey {
-					return step(rKey, rVal, tuple.Pair(tail, insertLeft(lKey, lValue, tuple.Second(acc))))
-				} else if lKey > rKey {
-					return tuple.MapSecond(func(r D) D {
-						return insertRight(rKey, rVal, r)
-					}, acc)
-				}
+// Apply a function to all keys in a dictionary. When several keys map to the
+// same new key, their values are combined from lowest to highest original key
+// as `combine(earlier, later)`.
+func MapKeysWith[A nub.Ord, B any, C nub.Ord](combine func(B, B) B, fn func(A) C, dict Dict[A, B]) Dict[C, B] {
+	return FoldL(func(key A, value B, acc Dict[C, B]) Dict[C, B] {
+		return InsertWith(combine, fn(key), value, acc)
+	}, Empty[C, B](), dict)
+}
 
-				return tuple.Pair(tail, insertBoth(lKey, lValue, rVal, tuple.Second(acc)))
+// Fold over the key-value pairs in a dictionary from lowest key to highest key.
+func FoldL[A nub.Ord, B any, C any](fn func(A, B, C) C, acc C, dict Dict[A, B]) C {
+	return rbtree.FoldL(fn, acc, dict.root)
+}
 
-			}, list.Head[tuple.Tuple[A, B]](xs), maybe.Just(list.Tail[tuple.Tuple[A, B]](xs))))
+// Fold over the key-value pairs in a dictionary from highest key to lowest key.
+func FoldR[A nub.Ord, B any, C any](fn func(A, B, C) C, acc C, dict Dict[A, B]) C {
+	return rbtree.FoldR(fn, acc, dict.root)
+}
+
+// Apply a function that may fail to every value in a dictionary. Returns
+// `Nothing` as soon as the function does, visiting keys from lowest to highest.
+func TraverseMaybe[A nub.Ord, B, C any](fn func(B) maybe.Maybe[C], dict Dict[A, B]) maybe.Maybe[Dict[A, C]] {
+	root, ok := rbtree.Traverse[A, B](func(value B) (C, bool) {
+		return maybe.Get[C](fn(value))
+	}, dict.root)
+	if !ok {
+		return maybe.Nothing[Dict[A, C]]()
 	}
+	return maybe.Just(Dict[A, C]{root: root})
+}
+
+// Turn a dictionary of optional values into an optional dictionary. Returns
+// `Nothing` if any value is `Nothing`.
+func SequenceMaybe[A nub.Ord, B any](dict Dict[A, maybe.Maybe[B]]) maybe.Maybe[Dict[A, B]] {
+	return TraverseMaybe(nub.Id[maybe.Maybe[B]], dict)
+}
 
-	intermediate := FoldL(step, tuple.Pair(left.dict, result), right)
+// Apply a function that may fail to every value in a dictionary. Returns the
+// first `Left`, visiting keys from lowest to highest.
+func TraverseEither[A nub.Ord, B, C, E any](fn func(B) either.Either[E, C], dict Dict[A, B]) either.Either[E, Dict[A, C]] {
+	var err E
+	root, ok := rbtree.Traverse[A, B](func(value B) (C, bool) {
+		c, e, ok := either.Get[E, C](fn(value))
+		err = e
+		return c, ok
+	}, dict.root)
+	if !ok {
+		return either.FromLeft[E, Dict[A, C]](err)
+	}
+	return either.FromRight[E](Dict[A, C]{root: root})
+}
 
-	return list.FoldL(func(pair tuple.Tuple[A, B], acc D) D {
-		return insertLeft(tuple.First(pair), tuple.Second(pair), acc)
-	}, tuple.Second(intermediate), tuple.First(intermediate))
+// Turn a dictionary of Eithers into an Either of a dictionary. Returns the
+// first `Left` by key order.
+func SequenceEither[A nub.Ord, B, E any](dict Dict[A, either.Either[E, B]]) either.Either[E, Dict[A, B]] {
+	return TraverseEither(nub.Id[either.Either[E, B]], dict)
 }
 
-// TRANSFORM
+// Find the value with the largest key, as computed by the given function. On
+// ties the value with the lowest dictionary key wins.
+func MaximumBy[A nub.Ord, B any, C nub.Ord](key func(B) C, dict Dict[A, B]) maybe.Maybe[B] {
+	return list.MaximumBy(key, Values(dict))
+}
 
-// Apply a function to all values in a dictionary.
-func Map[A nub.Ord, B, C any](fn func(B) C, dict Dict[A, B]) Dict[A, C] {
-	return Dict[A, C]{dict: list.Map(func(pair tuple.Tuple[A, B]) tuple.Tuple[A, C] {
-		return tuple.MapSecond(fn, pair)
-	}, dict.dict)}
+// Find the value with the smallest key, as computed by the given function. On
+// ties the value with the lowest dictionary key wins.
+func MinimumBy[A nub.Ord, B any, C nub.Ord](key func(B) C, dict Dict[A, B]) maybe.Maybe[B] {
+	return list.MinimumBy(key, Values(dict))
 }
 
-// Fold over the key-value pairs in a dictionary from lowest key to highest key.
-func FoldL[A nub.Ord, B any, C any](fn func(A, B, C) C, acc C, dict Dict[A, B]) C {
-	return list.FoldL(func(pair tuple.Tuple[A, B], acc C) C {
-		return fn(tuple.First(pair), tuple.Second(pair), acc)
-	}, acc, dict.dict)
+// Find the largest value according to a comparison function.
+func MaximumWith[A nub.Ord, B any](compare func(B, B) nub.Order, dict Dict[A, B]) maybe.Maybe[B] {
+	return list.MaximumWith(compare, Values(dict))
 }
 
-// Fold over the key-value pairs in a dictionary from highest key to lowest key.
-func FoldR[A nub.Ord, B any, C any](fn func(A, B, C) C, acc C, dict Dict[A, B]) C {
-	return list.FoldR(func(pair tuple.Tuple[A, B], acc C) C {
-		return fn(tuple.First(pair), tuple.Second(pair), acc)
-	}, acc, dict.dict)
+// Find the smallest value according to a comparison function.
+func MinimumWith[A nub.Ord, B any](compare func(B, B) nub.Order, dict Dict[A, B]) maybe.Maybe[B] {
+	return list.MinimumWith(compare, Values(dict))
 }
 
 // Keep only the key-value pairs that pass the given test.
 func Filter[A nub.Ord, B any](isGood func(A, B) bool, dict Dict[A, B]) Dict[A, B] {
-	return FoldR(func(key A, value B, acc Dict[A, B]) Dict[A, B] {
+	return FoldL(func(key A, value B, acc Dict[A, B]) Dict[A, B] {
 		if isGood(key, value) {
 			return Insert(key, value, acc)
 		}
@@ -195,24 +409,52 @@ func Partition[A nub.Ord, B any](isGood func(A, B) bool, dict Dict[A, B]) tuple.
 
 // LISTS
 
-// Convert an association list into a dictionary.
+// Convert an association list into a dictionary. If a key appears more than
+// once, the last value wins.
 func FromList[A nub.Ord, B any](ls list.List[tuple.Tuple[A, B]]) Dict[A, B] {
-	return Dict[A, B]{dict: list.SortBy(tuple.First[A, B], ls)}
+	return list.FoldL(func(pair tuple.Tuple[A, B], acc Dict[A, B]) Dict[A, B] {
+		return Insert(tuple.First(pair), tuple.Second(pair), acc)
+	}, Empty[A, B](), ls)
 }
 
 // Convert a dictionary into an association list of key-value pairs, sorted by keys.
 func ToList[A nub.Ord, B any](dict Dict[A, B]) list.List[tuple.Tuple[A, B]] {
-	return dict.dict
+	return FoldR(func(key A, value B, acc list.List[tuple.Tuple[A, B]]) list.List[tuple.Tuple[A, B]] {
+		return list.Cons(tuple.Pair(key, value), acc)
+	}, list.Nil[tuple.Tuple[A, B]](), dict)
 }
 
 // Get all of the keys in a dictionary, sorted from lowest to highest.
 func Keys[A nub.Ord, B any](dict Dict[A, B]) list.List[A] {
-	return list.Map(tuple.First[A, B], dict.dict)
+	return FoldR(func(key A, _ B, acc list.List[A]) list.List[A] {
+		return list.Cons(key, acc)
+	}, list.Nil[A](), dict)
 }
 
 // Get all of the values in a dictionary, in the order of their keys.
 func Values[A nub.Ord, B any](dict Dict[A, B]) list.List[B] {
-	return list.Map(tuple.Second[A, B], dict.dict)
+	return FoldR(func(_ A, value B, acc list.List[B]) list.List[B] {
+		return list.Cons(value, acc)
+	}, list.Nil[B](), dict)
+}
+
+// Group the elements of a list by a key. Each group keeps the elements in
+// the order they appear in the list.
+func GroupBy[A nub.Ord, B any](key func(B) A, ls list.List[B]) Dict[A, list.List[B]] {
+	return list.FoldR(func(value B, acc Dict[A, list.List[B]]) Dict[A, list.List[B]] {
+		k := key(value)
+		group := maybe.WithDefault(list.Nil[B](), Get(k, acc))
+		return Insert(k, list.Cons(value, group), acc)
+	}, Empty[A, list.List[B]](), ls)
+}
+
+// Count the elements of a list by a key.
+func CountBy[A nub.Ord, B any](key func(B) A, ls list.List[B]) Dict[A, int] {
+	return list.FoldL(func(value B, acc Dict[A, int]) Dict[A, int] {
+		return InsertWith(func(old int, one int) int {
+			return old + one
+		}, key(value), 1, acc)
+	}, Empty[A, int](), ls)
 }
 
 // GO maps
@@ -233,3 +475,85 @@ func ToGoMap[A nub.Ord, B any](dict Dict[A, B]) map[A]B {
 		return acc
 	}, map[A]B{}, dict)
 }
+
+// ITERATORS
+
+// An iterator over the key-value pairs in a dictionary, from lowest key to
+// highest key.
+func All[A nub.Ord, B any](dict Dict[A, B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		rbtree.All(yield, dict.root)
+	}
+}
+
+// Collect the key-value pairs of an iterator into a dictionary. If a key
+// appears more than once, the last value wins.
+func FromSeq2[A nub.Ord, B any](seq iter.Seq2[A, B]) Dict[A, B] {
+	dict := Empty[A, B]()
+	for k, v := range seq {
+		dict = Insert(k, v, dict)
+	}
+	return dict
+}
+
+// JSON
+
+// Dictionaries with string keys are encoded as a JSON object. Any other key
+// type is encoded as an array of `[key, value]` pairs. Either way the entries
+// are written from lowest key to highest key.
+func (dict Dict[A, B]) MarshalJSON() ([]byte, error) {
+	if !hasStringKeys[A]() {
+		return json.Marshal(list.ToSlice[tuple.Tuple[A, B]](ToList(dict)))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for k, v := range All(dict) {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Decode a dictionary from the encoding written by MarshalJSON. Decoding
+// `null` leaves the dictionary unchanged, as encoding/json expects.
+//
+// Values of interface types such as `list.List` or `maybe.Maybe` cannot be
+// decoded; use `list.JSON` or `maybe.JSON` as the value type instead.
+func (dict *Dict[A, B]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if !hasStringKeys[A]() {
+		var pairs []tuple.Tuple[A, B]
+		if err := json.Unmarshal(data, &pairs); err != nil {
+			return err
+		}
+		*dict = FromList[A, B](list.FromSlice(pairs))
+		return nil
+	}
+
+	var gomap map[A]B
+	if err := json.Unmarshal(data, &gomap); err != nil {
+		return err
+	}
+	*dict = FromGoMap(gomap)
+	return nil
+}
+
+func hasStringKeys[A nub.Ord]() bool {
+	return reflect.TypeFor[A]().Kind() == reflect.String
+}
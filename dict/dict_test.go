@@ -1,8 +1,15 @@
 package dict
 
 import (
+	"encoding/json"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
 	"testing"
 
+	"github.com/obiloud/curry-go/either"
+	"github.com/obiloud/curry-go/internal/rbtree"
 	"github.com/obiloud/curry-go/list"
 	"github.com/obiloud/curry-go/maybe"
 	"github.com/obiloud/curry-go/nub"
@@ -11,43 +18,47 @@ import (
 )
 
 func TestBuild(t *testing.T) {
-	if FromList[string, string](list.Nil[tuple.Tuple[string, string]]()) != Empty[string, string]() {
+	if !Equal(FromList[string, string](list.Nil[tuple.Tuple[string, string]]()), Empty[string, string]()) {
 		t.Error("Empty")
 	}
 
-	if FromList[string, string](list.Singleton(tuple.Pair("k", "v"))) != Singleton("k", "v") {
+	if !Equal(FromList[string, string](list.Singleton(tuple.Pair("k", "v"))), Singleton("k", "v")) {
 		t.Error("Singleton")
 	}
 
-	if Singleton("k", "v") != Insert("k", "v", Empty[string, string]()) {
+	if !Equal(Singleton("k", "v"), Insert("k", "v", Empty[string, string]())) {
 		t.Error("Insert")
 	}
 
-	if Singleton("k", "b") != Insert("k", "b", Singleton("k", "a")) {
+	if !Equal(Singleton("k", "b"), Insert("k", "b", Singleton("k", "a"))) {
 		t.Error("Insert replace")
 	}
 
+	if key := list.Head[float64](Keys(Insert(math.Copysign(0, -1), "b", Singleton(0.0, "a")))); math.Signbit(maybe.WithDefault(-1.0, key)) {
+		t.Error("Insert keeps the stored key")
+	}
+
 	updateWithValueFunc := nub.Const[maybe.Maybe[string], maybe.Maybe[string]](maybe.Just("b"))
 
-	if Singleton("k", "b") != Update("k", updateWithValueFunc, Singleton("k", "a")) {
+	if !Equal(Singleton("k", "b"), Update("k", updateWithValueFunc, Singleton("k", "a"))) {
 		t.Error("Update")
 	}
 
 	updateWithNothingFunc := nub.Const[maybe.Maybe[string], maybe.Maybe[string]](maybe.Nothing[string]())
 
-	if Empty[string, string]() != Update("k", updateWithNothingFunc, Singleton("k", "v")) {
+	if !Equal(Empty[string, string](), Update("k", updateWithNothingFunc, Singleton("k", "v"))) {
 		t.Error("Update Nothing")
 	}
 
-	if Empty[string, string]() != Remove("k", Singleton("k", "v")) {
+	if !Equal(Empty[string, string](), Remove("k", Singleton("k", "v"))) {
 		t.Error("Remove")
 	}
 
-	if Singleton("k", "v") != Remove("foo", Singleton("k", "v")) {
+	if !Equal(Singleton("k", "v"), Remove("foo", Singleton("k", "v"))) {
 		t.Error("Remove not found")
 	}
 
-	if FromGoMap(map[string]string{"k": "v"}) != Singleton("k", "v") {
+	if !Equal(FromGoMap(map[string]string{"k": "v"}), Singleton("k", "v")) {
 		t.Error("From Go Map")
 	}
 
@@ -85,34 +96,39 @@ func TestQuery(t *testing.T) {
 }
 
 func TestCombine(t *testing.T) {
-	if Union(Singleton("Jerry", "mouse"), Singleton("Tom", "cat")) != animals {
+	if !Equal(Union(Singleton("Jerry", "mouse"), Singleton("Tom", "cat")), animals) {
 		t.Errorf("Union")
 	}
 
-	if Union(Singleton("Tom", "cat"), Singleton("Tom", "cat")) != Singleton("Tom", "cat") {
+	if !Equal(Union(Singleton("Tom", "cat"), Singleton("Tom", "cat")), Singleton("Tom", "cat")) {
 		t.Errorf("Union collision")
 	}
 
-	if Intersect(Singleton("Tom", "cat"), animals) != Singleton("Tom", "cat") {
+	if !Equal(Union(Singleton("Tom", "cat"), Singleton("Tom", "dog")), Singleton("Tom", "cat")) {
+		t.Errorf("Union prefers first")
+	}
+
+	if !Equal(Intersect(Singleton("Tom", "cat"), animals), Singleton("Tom", "cat")) {
 		t.Error("Intersect")
 	}
 
-	if Diff(animals, Singleton("Tom", "cat")) != Singleton("Jerry", "mouse") {
+	if !Equal(Diff(animals, Singleton("Tom", "cat")), Singleton("Jerry", "mouse")) {
 		t.Error("Diff")
 	}
 }
 
 func TestTransform(t *testing.T) {
-	if Map(func(x int) int { return x + 1 }, FromList[string, int](list.Cons(tuple.Pair("a", 1), list.Singleton(tuple.Pair("b", 2))))) !=
-		FromList[string, int](list.Cons(tuple.Pair("a", 2), list.Singleton(tuple.Pair("b", 3)))) {
+	if !Equal(Map(func(x int) int { return x + 1 }, FromList[string, int](list.Cons(tuple.Pair("a", 1), list.Singleton(tuple.Pair("b", 2))))),
+		FromList[string, int](list.Cons(tuple.Pair("a", 2), list.Singleton(tuple.Pair("b", 3))))) {
 		t.Error("Map")
 	}
 
-	if Filter(func(k string, _ string) bool { return k == "Tom" }, animals) != Singleton("Tom", "cat") {
+	if !Equal(Filter(func(k string, _ string) bool { return k == "Tom" }, animals), Singleton("Tom", "cat")) {
 		t.Error("Filter")
 	}
 
-	if Partition(func(k string, _ string) bool { return k == "Tom" }, animals) != tuple.Pair(Singleton("Tom", "cat"), Singleton("Jerry", "mouse")) {
+	partitioned := Partition(func(k string, _ string) bool { return k == "Tom" }, animals)
+	if !Equal(tuple.First(partitioned), Singleton("Tom", "cat")) || !Equal(tuple.Second(partitioned), Singleton("Jerry", "mouse")) {
 		t.Error("Partition")
 	}
 }
@@ -123,14 +139,14 @@ func insertBoth[T nub.Ord](key T, valueLeft list.List[int], valueRight list.List
 
 func TestMerge(t *testing.T) {
 
-	if Merge(
+	if !Equal(Merge(
 		Insert[int, list.List[int]],
 		insertBoth[int],
 		Insert[int, list.List[int]],
 		Empty[int, list.List[int]](),
 		Empty[int, list.List[int]](),
 		Empty[int, list.List[int]](),
-	) != Empty[int, list.List[int]]() {
+	), Empty[int, list.List[int]]()) {
 		t.Error("Merge empties")
 	}
 
@@ -199,3 +215,402 @@ func TestMerge(t *testing.T) {
 		t.Error("Partially overlapping")
 	}
 }
+
+// Check the red-black invariants: no red right links, no two red links in a
+// row, and the same number of black nodes on every path from the root.
+func blackHeight[A nub.Ord, B any](t *testing.T, tr rbtree.Tree[A, B]) int {
+	n, ok := tr.(rbtree.Node[A, B])
+	if !ok {
+		return 1
+	}
+	if rbtree.IsRed[A, B](n.Right) {
+		t.Fatalf("red right link at %v", n.Key)
+	}
+	if n.Color == rbtree.Red && rbtree.IsRed[A, B](n.Left) {
+		t.Fatalf("two red links in a row at %v", n.Key)
+	}
+	l := blackHeight[A, B](t, n.Left)
+	r := blackHeight[A, B](t, n.Right)
+	if l != r {
+		t.Fatalf("black height mismatch at %v: %d vs %d", n.Key, l, r)
+	}
+	if n.Color == rbtree.Black {
+		return l + 1
+	}
+	return l
+}
+
+func TestBalance(t *testing.T) {
+	n := 20000
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = (i * 7919) % n
+	}
+
+	dict := Empty[int, int]()
+	for _, k := range keys {
+		dict = Insert(k, k*2, dict)
+	}
+	blackHeight[int, int](t, dict.root)
+
+	if Size(dict) != n {
+		t.Errorf("Size after %d inserts: %d", n, Size(dict))
+	}
+
+	if Keys(dict) != list.Range(0, n-1) {
+		t.Error("Keys are not sorted")
+	}
+
+	for _, k := range keys {
+		if Get(k, dict) != maybe.Just(k*2) {
+			t.Fatalf("Get %d", k)
+		}
+	}
+
+	for _, k := range keys {
+		if k%2 == 0 {
+			dict = Remove(k, dict)
+		}
+	}
+	blackHeight[int, int](t, dict.root)
+
+	if Size(dict) != n/2 {
+		t.Errorf("Size after removing: %d", Size(dict))
+	}
+
+	for _, k := range keys {
+		if Member(k, dict) != (k%2 == 1) {
+			t.Fatalf("Member %d after removing", k)
+		}
+	}
+
+	ascending := Empty[int, int]()
+	for i := 0; i < n; i++ {
+		ascending = Insert(i, i, ascending)
+	}
+	blackHeight[int, int](t, ascending.root)
+
+	for i := n - 1; i >= 0; i-- {
+		ascending = Remove(i, ascending)
+		if i%1000 == 0 {
+			blackHeight[int, int](t, ascending.root)
+		}
+	}
+
+	if !Equal(ascending, Empty[int, int]()) {
+		t.Error("Remove all")
+	}
+}
+
+func TestIterators(t *testing.T) {
+	keys := []string{}
+	for k, v := range All(animals) {
+		keys = append(keys, k)
+		if Get(k, animals) != maybe.Just(v) {
+			t.Errorf("All value for %s", k)
+		}
+	}
+	if !slices.Equal(keys, []string{"Jerry", "Tom"}) {
+		t.Error("All in key order")
+	}
+
+	if !Equal(FromSeq2(All(animals)), animals) {
+		t.Error("FromSeq2 round trip")
+	}
+
+	if !maps.Equal(maps.Collect(All(animals)), ToGoMap(animals)) {
+		t.Error("All with maps.Collect")
+	}
+
+	for range All(Empty[string, string]()) {
+		t.Error("All of Empty")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(animals)
+	if err != nil || string(data) != `{"Jerry":"mouse","Tom":"cat"}` {
+		t.Errorf("String keys encode as an object, got %s", data)
+	}
+
+	var decoded Dict[string, string]
+	if err := json.Unmarshal(data, &decoded); err != nil || !Equal(decoded, animals) {
+		t.Error("String keys round trip")
+	}
+
+	numbers := FromList[int, string](list.FromSlice([]tuple.Tuple[int, string]{tuple.Pair(2, "two"), tuple.Pair(1, "one")}))
+
+	data, err = json.Marshal(numbers)
+	if err != nil || string(data) != `[[1,"one"],[2,"two"]]` {
+		t.Errorf("Other keys encode as pairs, got %s", data)
+	}
+
+	var decodedNumbers Dict[int, string]
+	if err := json.Unmarshal(data, &decodedNumbers); err != nil || !Equal(decodedNumbers, numbers) {
+		t.Error("Other keys round trip")
+	}
+
+	data, err = json.Marshal(Empty[string, int]())
+	if err != nil || string(data) != `{}` {
+		t.Errorf("Empty with string keys, got %s", data)
+	}
+
+	data, err = json.Marshal(Singleton("k", list.Range(1, 2)))
+	if err != nil || string(data) != `{"k":[1,2]}` {
+		t.Errorf("List values, got %s", data)
+	}
+
+	var lists Dict[string, list.List[int]]
+	if err := json.Unmarshal(data, &lists); err == nil {
+		t.Error("List values cannot be decoded")
+	}
+
+	var wrapped Dict[string, list.JSON[int]]
+	if err := json.Unmarshal(data, &wrapped); err != nil || Map(list.JSON[int].List, wrapped).String() != `[("k", [1, 2])]` {
+		t.Error("list.JSON values round trip")
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &decoded); err != nil || !Equal(decoded, animals) {
+		t.Error("null leaves the dictionary unchanged")
+	}
+
+	if err := json.Unmarshal([]byte(`{"k":1}`), &decodedNumbers); err == nil {
+		t.Error("Object into non-string keys")
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	numbers := FromList[string, string](list.FromSlice([]tuple.Tuple[string, string]{tuple.Pair("a", "1"), tuple.Pair("b", "2")}))
+	parsed := FromList[string, int](list.FromSlice([]tuple.Tuple[string, int]{tuple.Pair("a", 1), tuple.Pair("b", 2)}))
+
+	parseMaybe := func(s string) maybe.Maybe[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return maybe.Nothing[int]()
+		}
+		return maybe.Just(n)
+	}
+
+	isParsed := func(m maybe.Maybe[Dict[string, int]]) bool {
+		dict, ok := maybe.Get[Dict[string, int]](m)
+		return ok && Equal(dict, parsed)
+	}
+
+	if !isParsed(TraverseMaybe(parseMaybe, numbers)) {
+		t.Error("TraverseMaybe all Just")
+	}
+
+	if !TraverseMaybe(parseMaybe, Insert("c", "x", numbers)).IsNothing() {
+		t.Error("TraverseMaybe with Nothing")
+	}
+
+	if !isParsed(SequenceMaybe[string, int](Map(parseMaybe, numbers))) {
+		t.Error("SequenceMaybe")
+	}
+
+	parseEither := func(s string) either.Either[string, int] {
+		return either.MapLeft[error, int](func(err error) string { return s }, either.FromResult(strconv.Atoi(s)))
+	}
+
+	if !isParsed(either.ToMaybe[string, Dict[string, int]](TraverseEither(parseEither, numbers))) {
+		t.Error("TraverseEither all Right")
+	}
+
+	if _, err, ok := either.Get[string, Dict[string, int]](TraverseEither(parseEither, Insert("d", "y", Insert("c", "x", numbers)))); ok || err != "x" {
+		t.Error("TraverseEither returns the first Left by key")
+	}
+
+	if !isParsed(either.ToMaybe[string, Dict[string, int]](SequenceEither[string, int, string](Map(parseEither, numbers)))) {
+		t.Error("SequenceEither")
+	}
+}
+
+func TestExtremes(t *testing.T) {
+	ages := FromList[string, int](list.FromSlice([]tuple.Tuple[string, int]{tuple.Pair("Tom", 7), tuple.Pair("Jerry", 3), tuple.Pair("Spike", 7)}))
+
+	if MaximumBy(nub.Id[int], ages) != maybe.Just(7) || MinimumBy(nub.Negate[int], ages) != maybe.Just(7) {
+		t.Error("MaximumBy / MinimumBy")
+	}
+
+	if MinimumWith(nub.Compare[int], ages) != maybe.Just(3) || MaximumWith(nub.Compare[int], ages) != maybe.Just(7) {
+		t.Error("MaximumWith / MinimumWith")
+	}
+
+	if MaximumBy(nub.Id[int], Empty[string, int]()) != maybe.Nothing[int]() {
+		t.Error("MaximumBy of Empty")
+	}
+}
+
+func TestOrder(t *testing.T) {
+	readings := Empty[int, string]()
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		readings = Insert(k, strconv.Itoa(k), readings)
+	}
+	none := maybe.Nothing[tuple.Tuple[int, string]]()
+
+	if Min(readings) != maybe.Just(tuple.Pair(10, "10")) || Max(readings) != maybe.Just(tuple.Pair(50, "50")) {
+		t.Error("Min / Max")
+	}
+
+	if Min(Empty[int, string]()) != none || Max(Empty[int, string]()) != none {
+		t.Error("Min / Max of an empty dictionary")
+	}
+
+	if Floor(35, readings) != maybe.Just(tuple.Pair(30, "30")) || Floor(30, readings) != maybe.Just(tuple.Pair(30, "30")) || Floor(5, readings) != none {
+		t.Error("Floor")
+	}
+
+	if Ceiling(35, readings) != maybe.Just(tuple.Pair(40, "40")) || Ceiling(40, readings) != maybe.Just(tuple.Pair(40, "40")) || Ceiling(55, readings) != none {
+		t.Error("Ceiling")
+	}
+
+	if Keys(Range(15, 40, readings)) != list.FromSlice([]int{20, 30, 40}) || !IsEmpty(Range(41, 49, readings)) || !IsEmpty(Range(40, 20, readings)) {
+		t.Error("Range")
+	}
+
+	split := Split(30, readings)
+	if Keys(tuple.First3(split)) != list.FromSlice([]int{10, 20}) {
+		t.Error("Split lower")
+	}
+	if tuple.Second3(split) != maybe.Just("30") || Keys(tuple.Third3(split)) != list.FromSlice([]int{40, 50}) {
+		t.Error("Split value and upper")
+	}
+	if tuple.Second3(Split(35, readings)) != maybe.Nothing[string]() || Size(tuple.First3(Split(35, readings))) != 3 {
+		t.Error("Split at a missing key")
+	}
+
+	if Keys(TakeSmallest(2, readings)) != list.FromSlice([]int{10, 20}) || !IsEmpty(TakeSmallest(0, readings)) || Size(TakeSmallest(9, readings)) != 5 {
+		t.Error("TakeSmallest")
+	}
+
+	if Keys(DropWhileKey(func(k int) bool { return k < 25 }, readings)) != list.FromSlice([]int{30, 40, 50}) || !IsEmpty(DropWhileKey(nub.Const[bool, int](true), readings)) {
+		t.Error("DropWhileKey")
+	}
+
+	visited := FoldRFrom(func(k int, _ string, acc []int) []int { return append(acc, k) }, nil, 35, readings)
+	if !slices.Equal(visited, []int{30, 20, 10}) {
+		t.Errorf("FoldRFrom visits keys downwards from the given key, got %v", visited)
+	}
+}
+
+func TestCombineWith(t *testing.T) {
+	plus := func(a int, b int) int { return a + b }
+	minus := func(a int, b int) int { return a - b }
+	sales := FromGoMap(map[string]int{"ann": 10, "bob": 5})
+	refunds := FromGoMap(map[string]int{"bob": 2, "cid": 1})
+
+	if Get("ann", InsertWith(minus, "ann", 3, sales)) != maybe.Just(7) || Get("dan", InsertWith(minus, "dan", 3, sales)) != maybe.Just(3) {
+		t.Error("InsertWith combines old and new")
+	}
+
+	if !maps.Equal(ToGoMap(UnionWith(minus, sales, refunds)), map[string]int{"ann": 10, "bob": 3, "cid": 1}) {
+		t.Error("UnionWith combines left and right")
+	}
+
+	totals := UnionsWith[string, int](plus, list.FromSlice([]Dict[string, int]{sales, refunds, Singleton("ann", 1)}))
+	if !maps.Equal(ToGoMap(totals), map[string]int{"ann": 11, "bob": 7, "cid": 1}) {
+		t.Error("UnionsWith")
+	}
+
+	if !IsEmpty(UnionsWith[string, int](plus, list.Nil[Dict[string, int]]())) {
+		t.Error("UnionsWith of no dictionaries")
+	}
+
+	net := IntersectWith(func(s int, r int) string { return strconv.Itoa(s - r) }, sales, refunds)
+	if !maps.Equal(ToGoMap(net), map[string]string{"bob": "3"}) {
+		t.Error("IntersectWith")
+	}
+
+	increment := func(m maybe.Maybe[int]) maybe.Maybe[int] {
+		return maybe.Just(maybe.WithDefault(0, m) + 1)
+	}
+	altered := Alter("bob", increment, sales)
+	if tuple.First(altered) != maybe.Just(5) || Get("bob", tuple.Second(altered)) != maybe.Just(6) {
+		t.Error("Alter returns the old value and the new dictionary")
+	}
+	removed := Alter("ann", nub.Const[maybe.Maybe[int], maybe.Maybe[int]](maybe.Nothing[int]()), sales)
+	if tuple.First(removed) != maybe.Just(10) || Member("ann", tuple.Second(removed)) {
+		t.Error("Alter removes")
+	}
+	if tuple.First(Alter("eve", increment, sales)) != maybe.Nothing[int]() {
+		t.Error("Alter on a missing key")
+	}
+}
+
+func TestMapVariants(t *testing.T) {
+	d := FromGoMap(map[int]string{1: "a", 2: "b", 3: "c", 4: "d"})
+
+	if Values(MapWithKey(func(k int, v string) string { return v + strconv.Itoa(k) }, d)) != list.FromSlice([]string{"a1", "b2", "c3", "d4"}) {
+		t.Error("MapWithKey")
+	}
+
+	odd := FilterMap(func(k int, v string) maybe.Maybe[string] {
+		if k%2 == 1 {
+			return maybe.Just(v + v)
+		}
+		return maybe.Nothing[string]()
+	}, d)
+	if !maps.Equal(ToGoMap(odd), map[int]string{1: "aa", 3: "cc"}) {
+		t.Error("FilterMap")
+	}
+
+	half := func(k int) int { return k / 2 }
+	if !maps.Equal(ToGoMap(MapKeys(half, d)), map[int]string{0: "a", 1: "c", 2: "d"}) {
+		t.Error("MapKeys keeps the value of the highest original key")
+	}
+
+	concat := func(a string, b string) string { return a + b }
+	if !maps.Equal(ToGoMap(MapKeysWith(concat, half, d)), map[int]string{0: "a", 1: "bc", 2: "d"}) {
+		t.Error("MapKeysWith combines in key order")
+	}
+
+	if Keys(MapKeys(strconv.Itoa, d)) != list.FromSlice([]string{"1", "2", "3", "4"}) {
+		t.Error("MapKeys changes the key type")
+	}
+}
+
+func TestGroup(t *testing.T) {
+	words := list.FromSlice([]string{"apple", "avocado", "banana", "cherry", "blueberry", "apricot"})
+	initial := func(s string) string { return s[:1] }
+
+	groups := GroupBy(initial, words)
+	if Keys(groups) != list.FromSlice([]string{"a", "b", "c"}) {
+		t.Error("GroupBy keys")
+	}
+	if Get("a", groups) != maybe.Just(list.FromSlice([]string{"apple", "avocado", "apricot"})) {
+		t.Error("GroupBy keeps list order within a group")
+	}
+
+	counts := CountBy(initial, words)
+	if !maps.Equal(ToGoMap(counts), map[string]int{"a": 3, "b": 2, "c": 1}) {
+		t.Error("CountBy")
+	}
+
+	if !IsEmpty(GroupBy(initial, list.Nil[string]())) || !IsEmpty(CountBy(initial, list.Nil[string]())) {
+		t.Error("grouping an empty list")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	pairs := []tuple.Tuple[int, string]{tuple.Pair(1, "a"), tuple.Pair(2, "b"), tuple.Pair(3, "c"), tuple.Pair(4, "d")}
+	ascending := FromList[int, string](list.FromSlice(pairs))
+	slices.Reverse(pairs)
+	descending := FromList[int, string](list.FromSlice(pairs))
+
+	if !Equal(ascending, descending) {
+		t.Error("Equal compares contents, not tree shape")
+	}
+
+	if Equal(ascending, Insert(4, "x", descending)) || Equal(ascending, Remove(4, descending)) || Equal(Remove(4, ascending), Remove(1, descending)) {
+		t.Error("Equal sees different values, sizes and keys")
+	}
+
+	if !Equal(Empty[int, string](), Remove(1, Singleton(1, "a"))) {
+		t.Error("empty dictionaries are equal")
+	}
+
+	sameLength := func(a string, b string) bool { return len(a) == len(b) }
+	if !EqualWith(sameLength, ascending, Insert(4, "x", descending)) {
+		t.Error("EqualWith uses the given equality for values")
+	}
+}
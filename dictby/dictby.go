@@ -0,0 +1,228 @@
+package dictby
+
+import (
+	"iter"
+
+	"github.com/obiloud/curry-go/internal/rbtree"
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+// A dictionary mapping unique keys to values, ordered by a comparator chosen
+// when the dictionary is created. Use it for keys that are not `nub.Ord`, such
+// as tuples, structs or `time.Time`, or to order strings differently.
+//
+// It is the same persistent red-black tree as `dict.Dict`, with the same
+// O(log n) operations and the same lowest-to-highest key order. Dictionaries
+// that are combined are expected to share the same comparator. The zero value
+// has no comparator; create dictionaries with Empty, Ordered or FromList.
+// Dictionaries cannot be compared with `==`; use Equal or EqualWith.
+type Dict[A any, B any] struct {
+	cmp  nub.Comparator[A]
+	root rbtree.Tree[A, B]
+}
+
+// Keys with a `Compare` method following the `cmp.Compare` convention, like
+// `time.Time`.
+type Comparable[A any] interface {
+	Compare(A) int
+}
+
+// Convert a dictionary into a string.
+func (dict Dict[A, B]) String() string {
+	return ToList(dict).String()
+}
+
+// Create an empty dictionary ordered by the given comparator.
+func Empty[A any, B any](cmp nub.Comparator[A]) Dict[A, B] {
+	return Dict[A, B]{cmp: cmp, root: rbtree.Leaf[A, B]{}}
+}
+
+// Create an empty dictionary ordered by the keys' own `Compare` method.
+func Ordered[A Comparable[A], B any]() Dict[A, B] {
+	return Empty[A, B](nub.FromCmp(A.Compare))
+}
+
+// Determine if a dictionary is empty.
+func IsEmpty[A any, B any](dict Dict[A, B]) bool {
+	return !dict.root.IsNode()
+}
+
+// Create a dictionary with one key-value pair.
+func Singleton[A any, B any](cmp nub.Comparator[A], key A, value B) Dict[A, B] {
+	return Dict[A, B]{cmp: cmp, root: rbtree.Singleton(key, value)}
+}
+
+// Get the value associated with a key. If the key is not found, return
+// `Nothing`.
+func Get[A any, B any](key A, dict Dict[A, B]) maybe.Maybe[B] {
+	if value, ok := rbtree.Get[A, B](dict.cmp, key, dict.root); ok {
+		return maybe.Just(value)
+	}
+	return maybe.Nothing[B]()
+}
+
+// Determine if a key is in a dictionary.
+func Member[A any, B any](key A, dict Dict[A, B]) bool {
+	return Get(key, dict).IsJust()
+}
+
+// Determine if two dictionaries hold the same keys with the same values. Keys
+// are matched with the first dictionary's comparator.
+func Equal[A any, B comparable](a Dict[A, B], b Dict[A, B]) bool {
+	return EqualWith(func(x B, y B) bool {
+		return x == y
+	}, a, b)
+}
+
+// Determine if two dictionaries hold the same keys, with values that are
+// equal according to the given function.
+func EqualWith[A any, B any](eq func(B, B) bool, a Dict[A, B], b Dict[A, B]) bool {
+	return rbtree.Equal(a.cmp, eq, a.root, b.root)
+}
+
+// Determine the number of key-value pairs in the dictionary.
+func Size[A any, B any](dict Dict[A, B]) int {
+	return rbtree.Size[A, B](dict.root)
+}
+
+// Insert a key-value pair into a dictionary. Replaces the key and value when
+// there is a collision. Unlike `dict.Insert`, which keeps the stored key, the
+// new key wins, because a comparator may treat visibly different keys (like
+// "Tom" and "tom") as the same.
+func Insert[A any, B any](key A, value B, dict Dict[A, B]) Dict[A, B] {
+	return Dict[A, B]{cmp: dict.cmp, root: rbtree.Replace(dict.cmp, key, value, dict.root)}
+}
+
+// Update the value of a dictionary for a specific key with a given function.
+func Update[A any, B any](key A, updateFunc func(maybe.Maybe[B]) maybe.Maybe[B], dict Dict[A, B]) Dict[A, B] {
+	if value, ok := maybe.Get[B](updateFunc(Get(key, dict))); ok {
+		return Insert(key, value, dict)
+	}
+	return Remove(key, dict)
+}
+
+// Remove a key-value pair from a dictionary. If the key is not found, no changes are made.
+func Remove[A any, B any](key A, dict Dict[A, B]) Dict[A, B] {
+	return Dict[A, B]{cmp: dict.cmp, root: rbtree.Remove[A, B](dict.cmp, key, dict.root)}
+}
+
+// COMBINE
+
+// Combine two dictionaries. If there is a collision, preference is given to the first dictionary.
+func Union[A any, B any](a Dict[A, B], b Dict[A, B]) Dict[A, B] {
+	return FoldL(Insert[A, B], b, a)
+}
+
+// Keep a key-value pair when its key appears in the second dictionary. Preference is given to values in the first dictionary.
+func Intersect[A any, B any](a Dict[A, B], b Dict[A, B]) Dict[A, B] {
+	return Filter(func(key A, _ B) bool {
+		return Member(key, b)
+	}, a)
+}
+
+// Keep a key-value pair when its key does not appear in the second dictionary.
+func Diff[A any, B any](a Dict[A, B], b Dict[A, B]) Dict[A, B] {
+	return FoldL(func(key A, _ B, acc Dict[A, B]) Dict[A, B] {
+		return Remove(key, acc)
+	}, a, b)
+}
+
+// The most general way of combining two dictionaries. You provide three
+// accumulators for when a given key appears:
+//  1. Only in the left dictionary.
+//  2. In both dictionaries.
+//  3. Only in the right dictionary.
+//
+// You then traverse all the keys from lowest to highest, as ordered by the
+// left dictionary's comparator, building up whatever you want.
+func Merge[A any, B, C, D any](insertLeft func(A, B, D) D, insertBoth func(A, B, C, D) D, insertRight func(A, C, D) D, left Dict[A, B], right Dict[A, C], result D) D {
+	return rbtree.Merge(left.cmp, insertLeft, insertBoth, insertRight, left.root, right.root, result)
+}
+
+// TRANSFORM
+
+// Apply a function to all values in a dictionary.
+func Map[A any, B, C any](fn func(B) C, dict Dict[A, B]) Dict[A, C] {
+	return Dict[A, C]{cmp: dict.cmp, root: rbtree.Map[A, B](func(_ A, value B) C {
+		return fn(value)
+	}, dict.root)}
+}
+
+// Fold over the key-value pairs in a dictionary from lowest key to highest key.
+func FoldL[A any, B any, C any](fn func(A, B, C) C, acc C, dict Dict[A, B]) C {
+	return rbtree.FoldL(fn, acc, dict.root)
+}
+
+// Fold over the key-value pairs in a dictionary from highest key to lowest key.
+func FoldR[A any, B any, C any](fn func(A, B, C) C, acc C, dict Dict[A, B]) C {
+	return rbtree.FoldR(fn, acc, dict.root)
+}
+
+// Keep only the key-value pairs that pass the given test.
+func Filter[A any, B any](isGood func(A, B) bool, dict Dict[A, B]) Dict[A, B] {
+	return FoldL(func(key A, value B, acc Dict[A, B]) Dict[A, B] {
+		if isGood(key, value) {
+			return Insert(key, value, acc)
+		}
+		return acc
+	}, Empty[A, B](dict.cmp), dict)
+}
+
+// Partition a dictionary according to some test. The first dictionary
+// contains all key-value pairs which passed the test, and the second contains
+// the pairs that did not.
+func Partition[A any, B any](isGood func(A, B) bool, dict Dict[A, B]) tuple.Tuple[Dict[A, B], Dict[A, B]] {
+	return FoldL(func(key A, value B, acc tuple.Tuple[Dict[A, B], Dict[A, B]]) tuple.Tuple[Dict[A, B], Dict[A, B]] {
+		insert := func(dict Dict[A, B]) Dict[A, B] {
+			return Insert(key, value, dict)
+		}
+		if isGood(key, value) {
+			return tuple.MapFirst(insert, acc)
+		}
+		return tuple.MapSecond(insert, acc)
+	}, tuple.Pair(Empty[A, B](dict.cmp), Empty[A, B](dict.cmp)), dict)
+}
+
+// LISTS
+
+// Convert an association list into a dictionary ordered by the given
+// comparator. If a key appears more than once, the last value wins.
+func FromList[A any, B any](cmp nub.Comparator[A], ls list.List[tuple.Tuple[A, B]]) Dict[A, B] {
+	return list.FoldL(func(pair tuple.Tuple[A, B], acc Dict[A, B]) Dict[A, B] {
+		return Insert(tuple.First(pair), tuple.Second(pair), acc)
+	}, Empty[A, B](cmp), ls)
+}
+
+// Convert a dictionary into an association list of key-value pairs, sorted by keys.
+func ToList[A any, B any](dict Dict[A, B]) list.List[tuple.Tuple[A, B]] {
+	return FoldR(func(key A, value B, acc list.List[tuple.Tuple[A, B]]) list.List[tuple.Tuple[A, B]] {
+		return list.Cons(tuple.Pair(key, value), acc)
+	}, list.Nil[tuple.Tuple[A, B]](), dict)
+}
+
+// Get all of the keys in a dictionary, sorted from lowest to highest.
+func Keys[A any, B any](dict Dict[A, B]) list.List[A] {
+	return FoldR(func(key A, _ B, acc list.List[A]) list.List[A] {
+		return list.Cons(key, acc)
+	}, list.Nil[A](), dict)
+}
+
+// Get all of the values in a dictionary, in the order of their keys.
+func Values[A any, B any](dict Dict[A, B]) list.List[B] {
+	return FoldR(func(_ A, value B, acc list.List[B]) list.List[B] {
+		return list.Cons(value, acc)
+	}, list.Nil[B](), dict)
+}
+
+// ITERATORS
+
+// An iterator over the key-value pairs in a dictionary, from lowest key to
+// highest key.
+func All[A any, B any](dict Dict[A, B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		rbtree.All(yield, dict.root)
+	}
+}
@@ -0,0 +1,156 @@
+package dictby
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+var caseless = nub.FromCmp(func(x string, y string) int {
+	return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+})
+
+func TestBuild(t *testing.T) {
+	dict := Insert("tom", "cat", Insert("Jerry", "mouse", Empty[string, string](caseless)))
+
+	if Get("TOM", dict) != maybe.Just("cat") || Get("jerry", dict) != maybe.Just("mouse") {
+		t.Error("Get uses the comparator")
+	}
+
+	if Get("spike", dict) != maybe.Nothing[string]() || Member("spike", dict) {
+		t.Error("Get missing key")
+	}
+
+	replaced := Insert("TOM", "kitten", dict)
+	if Size(replaced) != 2 || Get("tom", replaced) != maybe.Just("kitten") {
+		t.Error("Insert replaces keys that compare equal")
+	}
+
+	if !IsEmpty(Remove("JERRY", Remove("Tom", dict))) {
+		t.Error("Remove")
+	}
+
+	updated := Update("tom", func(m maybe.Maybe[string]) maybe.Maybe[string] {
+		return maybe.Map(strings.ToUpper, m)
+	}, dict)
+	if Get("tom", updated) != maybe.Just("CAT") {
+		t.Error("Update")
+	}
+
+	if Keys(dict).String() != `["Jerry", "tom"]` {
+		t.Errorf("Keys are ordered by the comparator, got %v", Keys(dict))
+	}
+}
+
+func TestTupleKeys(t *testing.T) {
+	cmp := tuple.Lexicographic(nub.Natural[string](), nub.Natural[int]())
+	dict := FromList[tuple.Tuple[string, int], string](cmp, list.FromSlice([]tuple.Tuple[tuple.Tuple[string, int], string]{
+		tuple.Pair(tuple.Pair("b", 1), "b1"),
+		tuple.Pair(tuple.Pair("a", 2), "a2"),
+		tuple.Pair(tuple.Pair("a", 1), "a1"),
+	}))
+
+	if Values(dict).String() != `["a1", "a2", "b1"]` {
+		t.Errorf("FromList orders tuple keys, got %v", Values(dict))
+	}
+
+	if Get(tuple.Pair("a", 2), dict) != maybe.Just("a2") {
+		t.Error("Get tuple key")
+	}
+}
+
+func TestOrdered(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dict := Ordered[time.Time, int]()
+	for _, h := range []int{5, 1, 3, 2, 4} {
+		dict = Insert(base.Add(time.Duration(h)*time.Hour), h, dict)
+	}
+
+	if Values(dict).String() != "[1, 2, 3, 4, 5]" {
+		t.Errorf("time keys are ordered by Compare, got %v", Values(dict))
+	}
+
+	sum := FoldL(func(_ time.Time, v int, acc int) int { return acc*10 + v }, 0, dict)
+	if sum != 12345 {
+		t.Errorf("FoldL goes from lowest to highest key, got %d", sum)
+	}
+
+	if Get(base.Add(3*time.Hour).In(time.FixedZone("x", 3600)), dict) != maybe.Just(3) {
+		t.Error("equal instants in other zones are the same key")
+	}
+}
+
+func TestCombine(t *testing.T) {
+	a := FromList[string, int](caseless, list.FromSlice([]tuple.Tuple[string, int]{tuple.Pair("a", 1), tuple.Pair("B", 2), tuple.Pair("c", 3)}))
+	b := FromList[string, int](caseless, list.FromSlice([]tuple.Tuple[string, int]{tuple.Pair("b", 20), tuple.Pair("D", 40)}))
+
+	if ToList(Union(a, b)).String() != `[("a", 1), ("B", 2), ("c", 3), ("D", 40)]` {
+		t.Errorf("Union prefers the first dictionary, got %v", ToList(Union(a, b)))
+	}
+
+	if Keys(Intersect(a, b)).String() != `["B"]` || Keys(Diff(a, b)).String() != `["a", "c"]` {
+		t.Error("Intersect / Diff")
+	}
+
+	merged := Merge(
+		func(k string, _ int, acc string) string { return acc + "L" + k },
+		func(k string, _ int, _ int, acc string) string { return acc + "B" + k },
+		func(k string, _ int, acc string) string { return acc + "R" + k },
+		a, b, "",
+	)
+	if merged != "LaBBLcRD" {
+		t.Errorf("Merge walks keys in comparator order, got %s", merged)
+	}
+
+	if Keys(Filter(func(_ string, v int) bool { return v > 1 }, a)).String() != `["B", "c"]` {
+		t.Error("Filter")
+	}
+
+	if Values(Map(func(v int) int { return v * 2 }, a)).String() != "[2, 4, 6]" {
+		t.Error("Map")
+	}
+}
+
+func TestReverseOrder(t *testing.T) {
+	dict := Empty[int, int](nub.Reverse(nub.Natural[int]()))
+	for i := 0; i < 1000; i++ {
+		dict = Insert(i, i, dict)
+	}
+	for i := 0; i < 1000; i += 2 {
+		dict = Remove(i, dict)
+	}
+
+	if Size(dict) != 500 || list.Head[int](Keys(dict)) != maybe.Just(999) {
+		t.Error("a reversed comparator orders from highest to lowest")
+	}
+
+	prev := 1000
+	for k := range All(dict) {
+		if k >= prev || k%2 == 0 {
+			t.Fatalf("All out of order at %d", k)
+		}
+		prev = k
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := FromList[string, int](caseless, list.FromSlice([]tuple.Tuple[string, int]{tuple.Pair("a", 1), tuple.Pair("b", 2), tuple.Pair("c", 3)}))
+	b := FromList[string, int](caseless, list.FromSlice([]tuple.Tuple[string, int]{tuple.Pair("C", 3), tuple.Pair("B", 2), tuple.Pair("A", 1)}))
+
+	if !Equal(a, b) {
+		t.Error("Equal matches keys with the comparator, regardless of tree shape")
+	}
+
+	if Equal(a, Insert("b", 20, b)) || Equal(a, Remove("a", b)) {
+		t.Error("Equal sees different values and sizes")
+	}
+
+	if !EqualWith(func(x int, y int) bool { return x%10 == y%10 }, a, Insert("b", 12, b)) {
+		t.Error("EqualWith uses the given equality for values")
+	}
+}
@@ -1,11 +1,14 @@
 package either
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/obiloud/curry-go/list"
 	"github.com/obiloud/curry-go/maybe"
 	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/tuple"
 	"github.com/obiloud/curry-go/util"
 )
 
@@ -47,6 +50,97 @@ func (r right[B]) String() string {
 	return fmt.Sprintf("Right(%s)", util.Stringify(r.obj))
 }
 
+// Either is encoded as a tagged object, `{"left": ...}` or `{"right": ...}`.
+// An error on the left is encoded as its message, unless it has its own
+// MarshalJSON.
+
+func (l left[A]) MarshalJSON() ([]byte, error) {
+	if _, ok := any(l.err).(json.Marshaler); !ok {
+		if err, ok := any(l.err).(error); ok {
+			return json.Marshal(map[string]string{"left": err.Error()})
+		}
+	}
+	return json.Marshal(map[string]A{"left": l.err})
+}
+
+func (r right[B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]B{"right": r.obj})
+}
+
+// Decode a tagged JSON object into an Either. Exactly one of the `left` and
+// `right` fields must be present. When A is `error`, the left side is read as
+// a message and decoded with `errors.New`.
+//
+// Either is an interface, so `json.Unmarshal` cannot decode into it when it is
+// a struct field, a map or dict value, or a tuple element. Use JSON in those
+// places instead.
+func FromJSON[A, B any](data []byte) (Either[A, B], error) {
+	var tagged struct {
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return nil, err
+	}
+	if (tagged.Left == nil) == (tagged.Right == nil) {
+		return nil, errors.New("either: expected exactly one of \"left\" or \"right\"")
+	}
+	if tagged.Left != nil {
+		var a A
+		if target, ok := any(&a).(*error); ok {
+			var msg string
+			if err := json.Unmarshal(tagged.Left, &msg); err != nil {
+				return nil, err
+			}
+			*target = errors.New(msg)
+			return FromLeft[A, B](a), nil
+		}
+		if err := json.Unmarshal(tagged.Left, &a); err != nil {
+			return nil, err
+		}
+		return FromLeft[A, B](a), nil
+	}
+	var b B
+	if err := json.Unmarshal(tagged.Right, &b); err != nil {
+		return nil, err
+	}
+	return FromRight[A](b), nil
+}
+
+// An Either that `json.Unmarshal` can decode, for struct fields and other
+// values nested inside JSON. It encodes the same way as the Either it holds.
+type JSON[A, B any] struct {
+	either Either[A, B]
+}
+
+// Wrap an Either so it can be encoded and decoded as part of a larger value.
+func AsJSON[A, B any](e Either[A, B]) JSON[A, B] {
+	return JSON[A, B]{either: e}
+}
+
+// The wrapped Either. Reports false for the zero value, such as a field that
+// was missing from the decoded JSON.
+func (j JSON[A, B]) Either() (Either[A, B], bool) {
+	return j.either, j.either != nil
+}
+
+func (j JSON[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.either)
+}
+
+// Decoding `null` leaves the value unchanged, as encoding/json expects.
+func (j *JSON[A, B]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	e, err := FromJSON[A, B](data)
+	if err != nil {
+		return err
+	}
+	j.either = e
+	return nil
+}
+
 func FromLeft[A, B any](err A) Either[A, B] {
 	return left[A]{err: err}
 }
@@ -58,7 +152,7 @@ func FromRight[A, B any](val B) Either[A, B] {
 func Lefts[A, B any](es list.List[Either[A, B]]) list.List[A] {
 	toLeft := func(e Either[A, B], ls list.List[A]) list.List[A] {
 		if e.IsLeft() {
-			list.Cons(e.(left[A]).err, ls)
+			return list.Cons(e.(left[A]).err, ls)
 		}
 		return ls
 	}
@@ -66,13 +160,39 @@ func Lefts[A, B any](es list.List[Either[A, B]]) list.List[A] {
 }
 
 func Rights[A, B any](es list.List[Either[A, B]]) list.List[B] {
-	toLeft := func(e Either[A, B], rs list.List[B]) list.List[B] {
-		if e.IsLeft() {
-			list.Cons(e.(right[B]).obj, rs)
+	toRight := func(e Either[A, B], rs list.List[B]) list.List[B] {
+		if e.IsRight() {
+			return list.Cons(e.(right[B]).obj, rs)
 		}
 		return rs
 	}
-	return list.FoldR(toLeft, list.Nil[B](), es)
+	return list.FoldR(toRight, list.Nil[B](), es)
+}
+
+func PartitionEithers[A, B any](es list.List[Either[A, B]]) tuple.Tuple[list.List[A], list.List[B]] {
+	step := func(e Either[A, B], pair tuple.Tuple[list.List[A], list.List[B]]) tuple.Tuple[list.List[A], list.List[B]] {
+		if e.IsLeft() {
+			return tuple.MapFirst(nub.Curry(list.Cons[A])(e.(left[A]).err), pair)
+		}
+		return tuple.MapSecond(nub.Curry(list.Cons[B])(e.(right[B]).obj), pair)
+	}
+	return list.FoldR(step, tuple.Pair(list.Nil[A](), list.Nil[B]()), es)
+}
+
+func Traverse[A, B, C any](fn func(B) Either[A, C], ls list.List[B]) Either[A, list.List[C]] {
+	slice := []C{}
+	for x := range list.Values[B](ls) {
+		e := fn(x)
+		if e.IsLeft() {
+			return FromLeft[A, list.List[C]](e.(left[A]).err)
+		}
+		slice = append(slice, e.(right[C]).obj)
+	}
+	return FromRight[A](list.FromSlice(slice))
+}
+
+func Sequence[A, B any](es list.List[Either[A, B]]) Either[A, list.List[B]] {
+	return Traverse(nub.Id[Either[A, B]], es)
 }
 
 func Map[A, B, C any](fn func(B) C, e Either[A, B]) Either[A, C] {
@@ -82,6 +202,27 @@ func Map[A, B, C any](fn func(B) C, e Either[A, B]) Either[A, C] {
 	return e
 }
 
+func MapLeft[A, B, C any](fn func(A) C, e Either[A, B]) Either[C, B] {
+	if e.IsLeft() {
+		return FromLeft[C, B](fn(e.(left[A]).err))
+	}
+	return e
+}
+
+func MapBoth[A, B, C, D any](f1 func(A) C, f2 func(B) D, e Either[A, B]) Either[C, D] {
+	if e.IsLeft() {
+		return FromLeft[C, D](f1(e.(left[A]).err))
+	}
+	return FromRight[C](f2(e.(right[B]).obj))
+}
+
+func Swap[A, B any](e Either[A, B]) Either[B, A] {
+	if e.IsLeft() {
+		return FromRight[B](e.(left[A]).err)
+	}
+	return FromLeft[B, A](e.(right[B]).obj)
+}
+
 func Map2[A, B, C, D any](fn func(B, C) D, e1 Either[A, B], e2 Either[A, C]) Either[A, D] {
 	if e1.IsLeft() {
 		return e1
@@ -103,6 +244,22 @@ func Bind[A, B, C any](fn func(B) Either[A, C], e Either[A, B]) Either[A, C] {
 	return fn(e.(right[B]).obj)
 }
 
+func Match[A, B, C any](e Either[A, B], onLeft func(A) C, onRight func(B) C) C {
+	if e.IsLeft() {
+		return onLeft(e.(left[A]).err)
+	}
+	return onRight(e.(right[B]).obj)
+}
+
+// Get the value of a Right, or the value of a Left. The boolean reports
+// whether the Either is a Right.
+func Get[A, B any](e Either[A, B]) (B, A, bool) {
+	if e.IsLeft() {
+		return *new(B), e.(left[A]).err, false
+	}
+	return e.(right[B]).obj, *new(A), true
+}
+
 func ToMaybe[A, B any](e Either[A, B]) maybe.Maybe[B] {
 	if e.IsRight() {
 		return maybe.Just(e.(right[B]).obj)
@@ -1,11 +1,15 @@
 package either
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"testing"
 
+	"github.com/obiloud/curry-go/list"
 	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/tuple"
 )
 
 func TestMap(t *testing.T) {
@@ -92,3 +96,160 @@ func TestFromMaybe(t *testing.T) {
 		t.Errorf("from nothing")
 	}
 }
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(FromLeft[string, int]("boom"))
+	if err != nil || string(data) != `{"left":"boom"}` {
+		t.Errorf("Left encoding, got %s", data)
+	}
+
+	data, err = json.Marshal(FromRight[string](42))
+	if err != nil || string(data) != `{"right":42}` {
+		t.Errorf("Right encoding, got %s", data)
+	}
+
+	if e, err := FromJSON[string, int]([]byte(`{"left":"boom"}`)); err != nil || e != FromLeft[string, int]("boom") {
+		t.Error("Left round trip")
+	}
+
+	if e, err := FromJSON[string, int]([]byte(`{"right":42}`)); err != nil || e != FromRight[string](42) {
+		t.Error("Right round trip")
+	}
+
+	if e, err := FromJSON[string, *int]([]byte(`{"right":null}`)); err != nil || e != FromRight[string, *int](nil) {
+		t.Error("Right null round trip")
+	}
+
+	if _, err := FromJSON[string, int]([]byte(`{}`)); err == nil {
+		t.Error("Neither side present")
+	}
+
+	if _, err := FromJSON[string, int]([]byte(`{"left":"boom","right":42}`)); err == nil {
+		t.Error("Both sides present")
+	}
+
+	data, err = json.Marshal(FromLeft[error, int](errors.New("boom")))
+	if err != nil || string(data) != `{"left":"boom"}` {
+		t.Errorf("errors encode as their message, got %s", data)
+	}
+
+	if e, err := FromJSON[error, int](data); err != nil || !e.IsLeft() || AsError[error, int](e).Error() != "boom" {
+		t.Errorf("error round trip, got %v", e)
+	}
+
+	type reply struct {
+		Result JSON[error, int] `json:"result"`
+		Retry  JSON[error, int] `json:"retry"`
+	}
+	data, err = json.Marshal(reply{Result: AsJSON[error, int](FromRight[error](42))})
+	if err != nil || string(data) != `{"result":{"right":42},"retry":null}` {
+		t.Errorf("JSON fields encode as Either, got %s", data)
+	}
+
+	var decoded reply
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := decoded.Result.Either(); !ok || e != FromRight[error](42) {
+		t.Error("JSON field round trip")
+	}
+	if _, ok := decoded.Retry.Either(); ok {
+		t.Error("a null JSON field holds no Either")
+	}
+}
+
+func TestMapLeft(t *testing.T) {
+	if MapLeft[int, string](strconv.Itoa, FromLeft[int, string](1)) != FromLeft[string, string]("1") {
+		t.Error("mapLeft Left")
+	}
+	if MapLeft[int, string](strconv.Itoa, FromRight[int]("x")) != FromRight[string]("x") {
+		t.Error("mapLeft Right")
+	}
+}
+
+func TestMapBoth(t *testing.T) {
+	length := func(s string) int {
+		return len(s)
+	}
+	if MapBoth[int, string](strconv.Itoa, length, FromLeft[int, string](1)) != FromLeft[string, int]("1") {
+		t.Error("mapBoth Left")
+	}
+	if MapBoth[int, string](strconv.Itoa, length, FromRight[int]("abc")) != FromRight[string](3) {
+		t.Error("mapBoth Right")
+	}
+}
+
+func TestSwap(t *testing.T) {
+	if Swap[string, int](FromLeft[string, int]("x")) != FromRight[int]("x") {
+		t.Error("swap Left")
+	}
+	if Swap[string, int](FromRight[string](1)) != FromLeft[int, string](1) {
+		t.Error("swap Right")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	describe := func(e Either[string, int]) string {
+		return Match(e, func(err string) string { return "error: " + err }, strconv.Itoa)
+	}
+	if describe(FromLeft[string, int]("x")) != "error: x" {
+		t.Error("match Left")
+	}
+	if describe(FromRight[string](1)) != "1" {
+		t.Error("match Right")
+	}
+}
+
+func TestGet(t *testing.T) {
+	if b, a, ok := Get[string, int](FromRight[string](1)); !ok || b != 1 || a != "" {
+		t.Error("get Right")
+	}
+	if b, a, ok := Get[string, int](FromLeft[string, int]("x")); ok || b != 0 || a != "x" {
+		t.Error("get Left")
+	}
+}
+
+var mixed = list.FromSlice([]Either[string, int]{
+	FromLeft[string, int]("a"),
+	FromRight[string](1),
+	FromLeft[string, int]("b"),
+	FromRight[string](2),
+})
+
+func TestLeftsRights(t *testing.T) {
+	if Lefts[string, int](mixed) != list.FromSlice([]string{"a", "b"}) {
+		t.Error("lefts")
+	}
+
+	if Rights[string, int](mixed) != list.Range(1, 2) {
+		t.Error("rights")
+	}
+
+	if PartitionEithers[string, int](mixed) != tuple.Pair(list.FromSlice([]string{"a", "b"}), list.Range(1, 2)) {
+		t.Error("partition eithers")
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	if Traverse(toInt, list.FromSlice([]string{"1", "2"})) != FromRight[string](list.Range(1, 2)) {
+		t.Error("traverse all Right")
+	}
+
+	calls := 0
+	counted := func(x string) Either[string, int] {
+		calls++
+		return toInt(x)
+	}
+
+	if Traverse(counted, list.FromSlice([]string{"1", "x", "y"})) != FromLeft[string, list.List[int]]("strconv.Atoi: parsing \"x\": invalid syntax") || calls != 2 {
+		t.Error("traverse stops at the first Left")
+	}
+
+	if Sequence[string, int](mixed) != FromLeft[string, list.List[int]]("a") {
+		t.Error("sequence with Left")
+	}
+
+	if Sequence[string, int](list.Nil[Either[string, int]]()) != FromRight[string](list.Nil[int]()) {
+		t.Error("sequence Nil")
+	}
+}
@@ -0,0 +1,72 @@
+package either
+
+import (
+	"fmt"
+)
+
+// The error made from a Left by AsError. It carries the Left value, and when
+// that value is itself an error it unwraps to it, so errors.Is and errors.As
+// see through the Left.
+type LeftError[A any] struct {
+	Value A
+}
+
+func (e LeftError[A]) Error() string {
+	if err, ok := any(e.Value).(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprint(e.Value)
+}
+
+func (e LeftError[A]) Unwrap() error {
+	if err, ok := any(e.Value).(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Turn a Left into a LeftError, for returning an Either where Go expects an
+// error. A Right gives nil.
+func AsError[A, B any](e Either[A, B]) error {
+	if e.IsLeft() {
+		return LeftError[A]{Value: e.(left[A]).err}
+	}
+	return nil
+}
+
+// Convert the result of a Go function call into an Either. A non-nil error
+// becomes a Left and the value is dropped.
+func FromResult[B any](val B, err error) Either[error, B] {
+	if err != nil {
+		return FromLeft[error, B](err)
+	}
+	return FromRight[error](val)
+}
+
+// Call a Go function that can fail and capture its result as an Either.
+func Try[B any](fn func() (B, error)) Either[error, B] {
+	return FromResult(fn())
+}
+
+// Call a function, turning a panic into a Left. A panic with an error value
+// keeps that error; any other value is wrapped in a new error.
+func Recover[B any](fn func() B) (result Either[error, B]) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("panic: %v", r)
+			}
+			result = FromLeft[error, B](err)
+		}
+	}()
+	return FromRight[error](fn())
+}
+
+// Convert an Either back into Go's value and error convention.
+func ToResult[A error, B any](e Either[A, B]) (B, error) {
+	if e.IsLeft() {
+		return *new(B), e.(left[A]).err
+	}
+	return e.(right[B]).obj, nil
+}
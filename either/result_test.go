@@ -0,0 +1,134 @@
+package either
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/obiloud/curry-go/list"
+)
+
+func TestFromResult(t *testing.T) {
+	if FromResult(strconv.Atoi("42")) != FromRight[error](42) {
+		t.Error("from a successful call")
+	}
+
+	e := FromResult(strconv.Atoi("x"))
+	if _, err, ok := Get[error, int](e); ok || !errors.Is(err, strconv.ErrSyntax) {
+		t.Error("from a failed call")
+	}
+}
+
+func TestTry(t *testing.T) {
+	parsed := Try(func() (int, error) {
+		return strconv.Atoi("42")
+	})
+	if parsed != FromRight[error](42) {
+		t.Error("try OK")
+	}
+
+	failed := Try(func() (int, error) {
+		return 0, io.EOF
+	})
+	if failed != FromLeft[error, int](io.EOF) {
+		t.Error("try Err")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	if Recover(func() int { return 1 }) != FromRight[error](1) {
+		t.Error("recover without panic")
+	}
+
+	withError := Recover(func() int {
+		panic(io.EOF)
+	})
+	if withError != FromLeft[error, int](io.EOF) {
+		t.Error("recover an error panic")
+	}
+
+	withValue := Recover(func() int {
+		var xs []int
+		return xs[1]
+	})
+	if withValue.IsRight() {
+		t.Error("recover a runtime panic")
+	}
+
+	withString := Recover(func() int {
+		panic("boom")
+	})
+	if _, err, _ := Get[error, int](withString); err == nil || err.Error() != "panic: boom" {
+		t.Error("recover a non-error panic")
+	}
+}
+
+func TestToResult(t *testing.T) {
+	if x, err := ToResult[error, int](FromRight[error](1)); x != 1 || err != nil {
+		t.Error("to result Right")
+	}
+
+	if x, err := ToResult[error, int](FromLeft[error, int](io.EOF)); x != 0 || err != io.EOF {
+		t.Error("to result Left")
+	}
+
+	if _, err := ToResult[error, int](Bind(isPositive, FromResult(strconv.Atoi("-1")))); err == nil || err.Error() != "-1 is not positive" {
+		t.Error("to result after Bind")
+	}
+}
+
+func isPositive(n int) Either[error, int] {
+	if n > 0 {
+		return FromRight[error](n)
+	}
+	return FromLeft[error, int](fmt.Errorf("%d is not positive", n))
+}
+
+func TestAsError(t *testing.T) {
+	_, openErr := os.Open("/does/not/exist")
+	err := AsError[error, int](FromLeft[error, int](fmt.Errorf("loading config: %w", openErr)))
+
+	if err == nil || !strings.HasPrefix(err.Error(), "loading config: ") {
+		t.Fatal("a Left becomes an error")
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("errors.Is sees through a Left")
+	}
+
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) || pathErr.Path != "/does/not/exist" {
+		t.Error("errors.As sees through a Left")
+	}
+
+	boom := AsError[string, int](FromLeft[string, int]("boom"))
+	var leftErr LeftError[string]
+	if boom.Error() != "boom" || !errors.As(boom, &leftErr) || leftErr.Value != "boom" || errors.Unwrap(boom) != nil {
+		t.Error("a Left of a non-error value")
+	}
+
+	if AsError[error, int](FromRight[error](1)) != nil {
+		t.Error("a Right is not an error")
+	}
+}
+
+func TestLeftPrinting(t *testing.T) {
+	e := FromLeft[string, int]("boom")
+
+	if _, ok := e.(error); ok {
+		t.Error("a Left is not itself an error")
+	}
+
+	if fmt.Sprint(e) != `Left("boom")` || e.String() != `Left("boom")` {
+		t.Errorf("fmt prints a Left with String, got %s", fmt.Sprint(e))
+	}
+
+	if list.Singleton(e).String() != `[Left("boom")]` {
+		t.Errorf("Stringify prints a Left with String, got %s", list.Singleton(e))
+	}
+}
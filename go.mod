@@ -1,5 +1,3 @@
 module github.com/obiloud/curry-go
 
-go 1.22
-
-toolchain go1.22.2
+go 1.23
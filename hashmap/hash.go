@@ -0,0 +1,106 @@
+package hashmap
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+var seed = maphash.MakeSeed()
+
+// Hash a key so that keys equal under `==` get equal hashes. Common key types
+// are hashed directly; any other comparable type is walked with reflection,
+// the way `==` would compare it.
+func hashOf[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(seed, k)
+	case int:
+		return hashUint(uint64(k))
+	case int64:
+		return hashUint(uint64(k))
+	case int32:
+		return hashUint(uint64(k))
+	case uint:
+		return hashUint(uint64(k))
+	case uint64:
+		return hashUint(k)
+	case uint32:
+		return hashUint(uint64(k))
+	}
+	var h maphash.Hash
+	h.SetSeed(seed)
+	writeValue(&h, reflect.ValueOf(&key).Elem())
+	return h.Sum64()
+}
+
+func hashUint(x uint64) uint64 {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	return maphash.Bytes(seed, b[:])
+}
+
+func writeUint(h *maphash.Hash, x uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	h.Write(b[:])
+}
+
+// Floats are written by bits, except that both zeros compare equal and must
+// hash the same. NaN never equals itself, so its hash does not matter.
+func writeFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		f = 0
+	}
+	writeUint(h, math.Float64bits(f))
+}
+
+func writeValue(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat(h, real(c))
+		writeFloat(h, imag(c))
+	case reflect.String:
+		// The length keeps ("ab", "c") and ("a", "bc") apart.
+		writeUint(h, uint64(v.Len()))
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint(h, uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+			return
+		}
+		e := v.Elem()
+		h.WriteString(e.Type().String())
+		writeValue(h, e)
+	case reflect.Array:
+		for i := range v.Len() {
+			writeValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		// Blank fields are skipped by `==`.
+		for i := range v.NumField() {
+			if v.Type().Field(i).Name != "_" {
+				writeValue(h, v.Field(i))
+			}
+		}
+	default:
+		// Like `==`, fail on an interface holding an uncomparable value.
+		panic("hashmap: hash of unhashable type " + v.Type().String())
+	}
+}
@@ -0,0 +1,470 @@
+package hashmap
+
+import (
+	"iter"
+	"math/bits"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+// A persistent hash map from comparable keys to values. Use it for keys that
+// are comparable but not ordered, when sorted iteration is not needed.
+//
+// Maps are hash array mapped tries: every level of the trie consumes five bits
+// of the key's hash, so Insert, Get and Remove visit at most log32(n) nodes
+// and share all untouched nodes with the map they were derived from.
+//
+// Folds and iterators visit the entries in hash order, which is stable for
+// the lifetime of the process but otherwise unspecified.
+type HashMap[K comparable, V any] struct {
+	root *branch[K, V]
+	size int
+}
+
+const (
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+)
+
+// A slot in a branch. It is either a single entry, a nested branch, or the
+// entries whose full hashes collide.
+type child[K comparable, V any] interface {
+	isChild()
+}
+
+type leaf[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+}
+
+type branch[K comparable, V any] struct {
+	bitmap   uint32
+	children []child[K, V]
+}
+
+type collision[K comparable, V any] struct {
+	hash   uint64
+	leaves []leaf[K, V]
+}
+
+func (leaf[K, V]) isChild()       {}
+func (*branch[K, V]) isChild()    {}
+func (*collision[K, V]) isChild() {}
+
+// The bit for a hash at the given depth and the position of its child in the
+// compressed children slice.
+func (b *branch[K, V]) slot(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & levelMask)
+	return bit, bits.OnesCount32(b.bitmap & (bit - 1))
+}
+
+func (b *branch[K, V]) with(pos int, c child[K, V]) *branch[K, V] {
+	children := make([]child[K, V], len(b.children))
+	copy(children, b.children)
+	children[pos] = c
+	return &branch[K, V]{bitmap: b.bitmap, children: children}
+}
+
+func (b *branch[K, V]) inserted(bit uint32, pos int, c child[K, V]) *branch[K, V] {
+	children := make([]child[K, V], 0, len(b.children)+1)
+	children = append(children, b.children[:pos]...)
+	children = append(children, c)
+	children = append(children, b.children[pos:]...)
+	return &branch[K, V]{bitmap: b.bitmap | bit, children: children}
+}
+
+func (b *branch[K, V]) removed(bit uint32, pos int) *branch[K, V] {
+	children := make([]child[K, V], 0, len(b.children)-1)
+	children = append(children, b.children[:pos]...)
+	children = append(children, b.children[pos+1:]...)
+	return &branch[K, V]{bitmap: b.bitmap &^ bit, children: children}
+}
+
+// The zero value is an empty map as well.
+func rootOf[K comparable, V any](m HashMap[K, V]) *branch[K, V] {
+	if m.root == nil {
+		return &branch[K, V]{}
+	}
+	return m.root
+}
+
+// Convert a map into a string.
+func (m HashMap[K, V]) String() string {
+	return ToList(m).String()
+}
+
+// Create an empty map.
+func Empty[K comparable, V any]() HashMap[K, V] {
+	return HashMap[K, V]{root: &branch[K, V]{}}
+}
+
+// Determine if a map is empty.
+func IsEmpty[K comparable, V any](m HashMap[K, V]) bool {
+	return m.size == 0
+}
+
+// Create a map with one key-value pair.
+func Singleton[K comparable, V any](key K, value V) HashMap[K, V] {
+	return Insert(key, value, Empty[K, V]())
+}
+
+// Determine the number of key-value pairs in the map.
+func Size[K comparable, V any](m HashMap[K, V]) int {
+	return m.size
+}
+
+// Get the value associated with a key. If the key is not found, return
+// `Nothing`.
+func Get[K comparable, V any](key K, m HashMap[K, V]) maybe.Maybe[V] {
+	if value, ok := getHashed(hashOf(key), key, m); ok {
+		return maybe.Just(value)
+	}
+	return maybe.Nothing[V]()
+}
+
+func getHashed[K comparable, V any](hash uint64, key K, m HashMap[K, V]) (V, bool) {
+	b := m.root
+	for shift := uint(0); b != nil; shift += bitsPerLevel {
+		bit, pos := b.slot(hash, shift)
+		if b.bitmap&bit == 0 {
+			break
+		}
+		switch c := b.children[pos].(type) {
+		case leaf[K, V]:
+			if c.key == key {
+				return c.value, true
+			}
+			b = nil
+		case *branch[K, V]:
+			b = c
+		case *collision[K, V]:
+			for _, l := range c.leaves {
+				if l.key == key {
+					return l.value, true
+				}
+			}
+			b = nil
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Determine if a key is in a map.
+func Member[K comparable, V any](key K, m HashMap[K, V]) bool {
+	return Get(key, m).IsJust()
+}
+
+// Insert a key-value pair into a map. Replaces value when there is a collision.
+func Insert[K comparable, V any](key K, value V, m HashMap[K, V]) HashMap[K, V] {
+	return insertHashed(hashOf(key), key, value, m)
+}
+
+func insertHashed[K comparable, V any](hash uint64, key K, value V, m HashMap[K, V]) HashMap[K, V] {
+	root, added := insertHelp(rootOf(m), 0, leaf[K, V]{hash: hash, key: key, value: value})
+	if added {
+		return HashMap[K, V]{root: root, size: m.size + 1}
+	}
+	return HashMap[K, V]{root: root, size: m.size}
+}
+
+func insertHelp[K comparable, V any](b *branch[K, V], shift uint, l leaf[K, V]) (*branch[K, V], bool) {
+	bit, pos := b.slot(l.hash, shift)
+	if b.bitmap&bit == 0 {
+		return b.inserted(bit, pos, l), true
+	}
+	switch c := b.children[pos].(type) {
+	case leaf[K, V]:
+		if c.key == l.key {
+			return b.with(pos, l), false
+		}
+		return b.with(pos, join(shift+bitsPerLevel, c.hash, child[K, V](c), l)), true
+	case *branch[K, V]:
+		nb, added := insertHelp(c, shift+bitsPerLevel, l)
+		return b.with(pos, nb), added
+	case *collision[K, V]:
+		if c.hash != l.hash {
+			return b.with(pos, join(shift+bitsPerLevel, c.hash, child[K, V](c), l)), true
+		}
+		leaves := make([]leaf[K, V], len(c.leaves), len(c.leaves)+1)
+		copy(leaves, c.leaves)
+		for i, existing := range leaves {
+			if existing.key == l.key {
+				leaves[i] = l
+				return b.with(pos, &collision[K, V]{hash: c.hash, leaves: leaves}), false
+			}
+		}
+		return b.with(pos, &collision[K, V]{hash: c.hash, leaves: append(leaves, l)}), true
+	}
+	return b, false
+}
+
+// Combine an existing child with a new leaf that landed in the same slot,
+// pushing both down until their hashes diverge.
+func join[K comparable, V any](shift uint, hash uint64, existing child[K, V], l leaf[K, V]) child[K, V] {
+	if hash == l.hash {
+		if c, ok := existing.(leaf[K, V]); ok {
+			return &collision[K, V]{hash: hash, leaves: []leaf[K, V]{c, l}}
+		}
+	}
+	b := &branch[K, V]{}
+	bit, _ := b.slot(hash, shift)
+	b = b.inserted(bit, 0, existing)
+	nb, _ := insertHelp(b, shift, l)
+	return nb
+}
+
+// Update the value of a map for a specific key with a given function.
+func Update[K comparable, V any](key K, updateFunc func(maybe.Maybe[V]) maybe.Maybe[V], m HashMap[K, V]) HashMap[K, V] {
+	if value, ok := maybe.Get[V](updateFunc(Get(key, m))); ok {
+		return Insert(key, value, m)
+	}
+	return Remove(key, m)
+}
+
+// Remove a key-value pair from a map. If the key is not found, no changes are made.
+func Remove[K comparable, V any](key K, m HashMap[K, V]) HashMap[K, V] {
+	return removeHashed(hashOf(key), key, m)
+}
+
+func removeHashed[K comparable, V any](hash uint64, key K, m HashMap[K, V]) HashMap[K, V] {
+	root, removed := removeHelp(rootOf(m), 0, hash, key)
+	if !removed {
+		return m
+	}
+	return HashMap[K, V]{root: root, size: m.size - 1}
+}
+
+func removeHelp[K comparable, V any](b *branch[K, V], shift uint, hash uint64, key K) (*branch[K, V], bool) {
+	bit, pos := b.slot(hash, shift)
+	if b.bitmap&bit == 0 {
+		return b, false
+	}
+	switch c := b.children[pos].(type) {
+	case leaf[K, V]:
+		if c.key != key {
+			return b, false
+		}
+		return b.removed(bit, pos), true
+	case *branch[K, V]:
+		nb, removed := removeHelp(c, shift+bitsPerLevel, hash, key)
+		if !removed {
+			return b, false
+		}
+		switch {
+		case len(nb.children) == 0:
+			return b.removed(bit, pos), true
+		case len(nb.children) == 1:
+			// Pull a lone leaf or collision up so lookups stay short.
+			if _, isBranch := nb.children[0].(*branch[K, V]); !isBranch {
+				return b.with(pos, nb.children[0]), true
+			}
+		}
+		return b.with(pos, nb), true
+	case *collision[K, V]:
+		for i, l := range c.leaves {
+			if l.key != key {
+				continue
+			}
+			if len(c.leaves) == 2 {
+				return b.with(pos, c.leaves[1-i]), true
+			}
+			leaves := make([]leaf[K, V], 0, len(c.leaves)-1)
+			leaves = append(leaves, c.leaves[:i]...)
+			leaves = append(leaves, c.leaves[i+1:]...)
+			return b.with(pos, &collision[K, V]{hash: c.hash, leaves: leaves}), true
+		}
+	}
+	return b, false
+}
+
+// COMBINE
+
+// Combine two maps. If there is a collision, preference is given to the first map.
+func Union[K comparable, V any](a HashMap[K, V], b HashMap[K, V]) HashMap[K, V] {
+	return FoldL(Insert[K, V], b, a)
+}
+
+// Keep a key-value pair when its key appears in the second map. Preference is given to values in the first map.
+func Intersect[K comparable, V any](a HashMap[K, V], b HashMap[K, V]) HashMap[K, V] {
+	return Filter(func(key K, _ V) bool {
+		return Member(key, b)
+	}, a)
+}
+
+// Keep a key-value pair when its key does not appear in the second map.
+func Diff[K comparable, V any](a HashMap[K, V], b HashMap[K, V]) HashMap[K, V] {
+	return FoldL(func(key K, _ V, acc HashMap[K, V]) HashMap[K, V] {
+		return Remove(key, acc)
+	}, a, b)
+}
+
+// The most general way of combining two maps. You provide three accumulators
+// for when a given key appears:
+//  1. Only in the left map.
+//  2. In both maps.
+//  3. Only in the right map.
+//
+// Keys of the left map are visited first, then the keys only found in the
+// right map.
+func Merge[K comparable, A, B, R any](insertLeft func(K, A, R) R, insertBoth func(K, A, B, R) R, insertRight func(K, B, R) R, left HashMap[K, A], right HashMap[K, B], result R) R {
+	result = FoldL(func(key K, a A, acc R) R {
+		if b, ok := maybe.Get[B](Get(key, right)); ok {
+			return insertBoth(key, a, b, acc)
+		}
+		return insertLeft(key, a, acc)
+	}, result, left)
+
+	return FoldL(func(key K, b B, acc R) R {
+		if Member(key, left) {
+			return acc
+		}
+		return insertRight(key, b, acc)
+	}, result, right)
+}
+
+// TRANSFORM
+
+// Apply a function to all values in a map. The result has the same shape as
+// the original.
+func Map[K comparable, A, B any](fn func(A) B, m HashMap[K, A]) HashMap[K, B] {
+	return HashMap[K, B]{root: mapHelp(fn, rootOf(m)), size: m.size}
+}
+
+func mapHelp[K comparable, A, B any](fn func(A) B, b *branch[K, A]) *branch[K, B] {
+	children := make([]child[K, B], len(b.children))
+	for i, c := range b.children {
+		switch c := c.(type) {
+		case leaf[K, A]:
+			children[i] = leaf[K, B]{hash: c.hash, key: c.key, value: fn(c.value)}
+		case *branch[K, A]:
+			children[i] = mapHelp(fn, c)
+		case *collision[K, A]:
+			leaves := make([]leaf[K, B], len(c.leaves))
+			for j, l := range c.leaves {
+				leaves[j] = leaf[K, B]{hash: l.hash, key: l.key, value: fn(l.value)}
+			}
+			children[i] = &collision[K, B]{hash: c.hash, leaves: leaves}
+		}
+	}
+	return &branch[K, B]{bitmap: b.bitmap, children: children}
+}
+
+// Fold over the key-value pairs in a map, in hash order.
+func FoldL[K comparable, V any, R any](fn func(K, V, R) R, acc R, m HashMap[K, V]) R {
+	for k, v := range All(m) {
+		acc = fn(k, v, acc)
+	}
+	return acc
+}
+
+// Keep only the key-value pairs that pass the given test.
+func Filter[K comparable, V any](isGood func(K, V) bool, m HashMap[K, V]) HashMap[K, V] {
+	return FoldL(func(key K, value V, acc HashMap[K, V]) HashMap[K, V] {
+		if isGood(key, value) {
+			return acc
+		}
+		return Remove(key, acc)
+	}, m, m)
+}
+
+// Partition a map according to some test. The first map contains all
+// key-value pairs which passed the test, and the second contains the pairs
+// that did not.
+func Partition[K comparable, V any](isGood func(K, V) bool, m HashMap[K, V]) tuple.Tuple[HashMap[K, V], HashMap[K, V]] {
+	return tuple.Pair(
+		Filter(isGood, m),
+		Filter(func(key K, value V) bool { return !isGood(key, value) }, m),
+	)
+}
+
+// LISTS
+
+// Convert an association list into a map. If a key appears more than once,
+// the last value wins.
+func FromList[K comparable, V any](ls list.List[tuple.Tuple[K, V]]) HashMap[K, V] {
+	return list.FoldL(func(pair tuple.Tuple[K, V], acc HashMap[K, V]) HashMap[K, V] {
+		return Insert(tuple.First(pair), tuple.Second(pair), acc)
+	}, Empty[K, V](), ls)
+}
+
+// Convert a map into an association list of key-value pairs, in hash order.
+func ToList[K comparable, V any](m HashMap[K, V]) list.List[tuple.Tuple[K, V]] {
+	return list.Reverse[tuple.Tuple[K, V]](FoldL(func(key K, value V, acc list.List[tuple.Tuple[K, V]]) list.List[tuple.Tuple[K, V]] {
+		return list.Cons(tuple.Pair(key, value), acc)
+	}, list.Nil[tuple.Tuple[K, V]](), m))
+}
+
+// Get all of the keys in a map, in hash order.
+func Keys[K comparable, V any](m HashMap[K, V]) list.List[K] {
+	return list.Map(tuple.First[K, V], ToList(m))
+}
+
+// Get all of the values in a map, in hash order.
+func Values[K comparable, V any](m HashMap[K, V]) list.List[V] {
+	return list.Map(tuple.Second[K, V], ToList(m))
+}
+
+// GO maps
+
+// Convert a golang map into a hash map.
+func FromGoMap[K comparable, V any](gomap map[K]V) HashMap[K, V] {
+	m := Empty[K, V]()
+	for k, v := range gomap {
+		m = Insert(k, v, m)
+	}
+	return m
+}
+
+// Convert a hash map into a golang map.
+func ToGoMap[K comparable, V any](m HashMap[K, V]) map[K]V {
+	gomap := make(map[K]V, m.size)
+	for k, v := range All(m) {
+		gomap[k] = v
+	}
+	return gomap
+}
+
+// ITERATORS
+
+// An iterator over the key-value pairs in a map, in hash order.
+func All[K comparable, V any](m HashMap[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		allHelp(yield, rootOf(m))
+	}
+}
+
+func allHelp[K comparable, V any](yield func(K, V) bool, b *branch[K, V]) bool {
+	for _, c := range b.children {
+		switch c := c.(type) {
+		case leaf[K, V]:
+			if !yield(c.key, c.value) {
+				return false
+			}
+		case *branch[K, V]:
+			if !allHelp(yield, c) {
+				return false
+			}
+		case *collision[K, V]:
+			for _, l := range c.leaves {
+				if !yield(l.key, l.value) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Collect the key-value pairs of an iterator into a map. If a key appears
+// more than once, the last value wins.
+func FromSeq2[K comparable, V any](seq iter.Seq2[K, V]) HashMap[K, V] {
+	m := Empty[K, V]()
+	for k, v := range seq {
+		m = Insert(k, v, m)
+	}
+	return m
+}
@@ -0,0 +1,215 @@
+package hashmap
+
+import (
+	"maps"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+type userID struct {
+	org  int
+	user int
+}
+
+func TestBuild(t *testing.T) {
+	m := Insert(userID{1, 2}, "ann", Insert(userID{1, 3}, "bob", Empty[userID, string]()))
+
+	if Get(userID{1, 2}, m) != maybe.Just("ann") || Get(userID{2, 2}, m) != maybe.Nothing[string]() {
+		t.Error("Get struct keys")
+	}
+
+	if Size(m) != 2 || Size(Insert(userID{1, 2}, "anna", m)) != 2 {
+		t.Error("Insert replaces existing keys")
+	}
+
+	if Size(Remove(userID{9, 9}, m)) != 2 || !IsEmpty(Remove(userID{1, 3}, Remove(userID{1, 2}, m))) {
+		t.Error("Remove")
+	}
+
+	if Get(userID{1, 2}, m) != maybe.Just("ann") {
+		t.Error("older versions are unchanged")
+	}
+
+	var zero HashMap[string, int]
+	if !IsEmpty(zero) || Member("a", zero) || Get("a", Insert("a", 1, zero)) != maybe.Just(1) {
+		t.Error("the zero value is an empty map")
+	}
+
+	updated := Update("a", func(m maybe.Maybe[int]) maybe.Maybe[int] {
+		return maybe.Map(func(x int) int { return x + 1 }, m)
+	}, Singleton("a", 1))
+	if Get("a", updated) != maybe.Just(2) {
+		t.Error("Update")
+	}
+}
+
+func TestLarge(t *testing.T) {
+	n := 100000
+	r := rand.New(rand.NewSource(1))
+	want := map[int]int{}
+	m := Empty[int, int]()
+	for i := 0; i < n; i++ {
+		k := r.Intn(n)
+		want[k] = i
+		m = Insert(k, i, m)
+	}
+
+	if Size(m) != len(want) {
+		t.Fatalf("Size %d, want %d", Size(m), len(want))
+	}
+	if !maps.Equal(ToGoMap(m), want) {
+		t.Fatal("ToGoMap after inserts")
+	}
+
+	for k := range want {
+		if k%3 == 0 {
+			delete(want, k)
+			m = Remove(k, m)
+		}
+	}
+	if Size(m) != len(want) || !maps.Equal(ToGoMap(m), want) {
+		t.Fatal("ToGoMap after removes")
+	}
+
+	for k := 0; k < n; k++ {
+		_, ok := want[k]
+		if Member(k, m) != ok {
+			t.Fatalf("Member %d", k)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	if hashOf(0.0) != hashOf(negZero) || Get(negZero, Singleton(0.0, "zero")) != maybe.Just("zero") {
+		t.Error("both zeros are the same key")
+	}
+
+	type key struct {
+		name  string
+		x     float64
+		_     int
+		inner [2]any
+	}
+	a := key{name: "a", x: 0, inner: [2]any{1, "b"}}
+	b := key{name: "a", x: negZero, inner: [2]any{1, "b"}}
+	if hashOf(a) != hashOf(b) || Get(b, Singleton(a, 1)) != maybe.Just(1) {
+		t.Error("equal structs are the same key")
+	}
+	if Size(Insert(key{name: "a", x: 0, inner: [2]any{int64(1), "b"}}, 2, Singleton(a, 1))) != 2 {
+		t.Error("interfaces holding different types are different keys")
+	}
+
+	x, y := 1, 1
+	if Size(Insert(&x, "x", Insert(&y, "y", Empty[*int, string]()))) != 2 || Get(&x, Singleton(&x, "x")) != maybe.Just("x") {
+		t.Error("pointers are keyed by address")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("uncomparable values in an interface key panic")
+		}
+	}()
+	Singleton[any](any([]int{1}), 1)
+}
+
+func TestCollisions(t *testing.T) {
+	m := Empty[string, int]()
+	m = insertHashed(42, "a", 1, m)
+	m = insertHashed(42, "b", 2, m)
+	m = insertHashed(42|1<<40, "c", 3, m)
+	m = insertHashed(42, "d", 4, m)
+	m = insertHashed(42, "b", 20, m)
+
+	if Size(m) != 4 {
+		t.Errorf("Size %d", Size(m))
+	}
+
+	for k, v := range map[string]int{"a": 1, "b": 20, "c": 3, "d": 4} {
+		h := uint64(42)
+		if k == "c" {
+			h |= 1 << 40
+		}
+		if got, ok := getHashed(h, k, m); !ok || got != v {
+			t.Errorf("get %s: %d %v", k, got, ok)
+		}
+	}
+
+	m = removeHashed(42, "a", m)
+	m = removeHashed(42, "d", m)
+	m = removeHashed(42, "x", m)
+	if Size(m) != 2 {
+		t.Errorf("Size after removes %d", Size(m))
+	}
+	if got, ok := getHashed(42, "b", m); !ok || got != 20 {
+		t.Error("collision collapses to a leaf")
+	}
+
+	m = removeHashed(42, "b", m)
+	m = removeHashed(42|1<<40, "c", m)
+	if !IsEmpty(m) || len(m.root.children) != 0 {
+		t.Error("removing everything leaves an empty root")
+	}
+}
+
+func TestCombine(t *testing.T) {
+	a := FromGoMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	b := FromGoMap(map[string]int{"b": 20, "d": 40})
+
+	if !maps.Equal(ToGoMap(Union(a, b)), map[string]int{"a": 1, "b": 2, "c": 3, "d": 40}) {
+		t.Error("Union prefers the first map")
+	}
+
+	if !maps.Equal(ToGoMap(Intersect(a, b)), map[string]int{"b": 2}) {
+		t.Error("Intersect")
+	}
+
+	if !maps.Equal(ToGoMap(Diff(a, b)), map[string]int{"a": 1, "c": 3}) {
+		t.Error("Diff")
+	}
+
+	merged := Merge(
+		func(k string, _ int, acc map[string]string) map[string]string { acc[k] = "left"; return acc },
+		func(k string, _ int, _ int, acc map[string]string) map[string]string { acc[k] = "both"; return acc },
+		func(k string, _ int, acc map[string]string) map[string]string { acc[k] = "right"; return acc },
+		a, b, map[string]string{},
+	)
+	if !maps.Equal(merged, map[string]string{"a": "left", "b": "both", "c": "left", "d": "right"}) {
+		t.Errorf("Merge %v", merged)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	m := FromList[string, int](list.FromSlice([]tuple.Tuple[string, int]{tuple.Pair("a", 1), tuple.Pair("b", 2), tuple.Pair("c", 3), tuple.Pair("a", 4)}))
+
+	if !maps.Equal(ToGoMap(m), map[string]int{"a": 4, "b": 2, "c": 3}) {
+		t.Error("FromList keeps the last duplicate")
+	}
+
+	if !maps.Equal(ToGoMap(Map(func(x int) int { return x * 10 }, m)), map[string]int{"a": 40, "b": 20, "c": 30}) {
+		t.Error("Map")
+	}
+
+	if FoldL(func(_ string, v int, acc int) int { return acc + v }, 0, m) != 9 {
+		t.Error("FoldL")
+	}
+
+	even := func(_ string, v int) bool { return v%2 == 0 }
+	parts := Partition(even, m)
+	if !maps.Equal(ToGoMap(Filter(even, m)), map[string]int{"a": 4, "b": 2}) || !maps.Equal(ToGoMap(tuple.Second(parts)), map[string]int{"c": 3}) {
+		t.Error("Filter / Partition")
+	}
+
+	if list.Length[string](Keys(m)) != 3 || list.Sum[int](Values(m)) != 9 {
+		t.Error("Keys / Values")
+	}
+
+	if !maps.Equal(ToGoMap(FromSeq2(maps.All(map[string]int{"x": 1}))), map[string]int{"x": 1}) {
+		t.Error("FromSeq2")
+	}
+}
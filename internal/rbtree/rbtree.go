@@ -0,0 +1,493 @@
+// Package rbtree implements the persistent left-leaning red-black tree shared
+// by the ordered dictionaries. Every operation that needs to order keys takes
+// the comparator explicitly, so the same tree serves `nub.Ord` keys and keys
+// ordered by a user-supplied comparator.
+package rbtree
+
+import (
+	"iter"
+
+	"github.com/obiloud/curry-go/nub"
+)
+
+// The color of a node in the red-black tree.
+type Color int
+
+const (
+	Red Color = iota
+	Black
+)
+
+type Tree[A any, B any] interface {
+	IsNode() bool
+}
+
+type Leaf[A any, B any] struct{}
+
+type Node[A any, B any] struct {
+	Color Color
+	Key   A
+	Value B
+	Left  Tree[A, B]
+	Right Tree[A, B]
+}
+
+func (l Leaf[A, B]) IsNode() bool {
+	return false
+}
+
+func (n Node[A, B]) IsNode() bool {
+	return true
+}
+
+func IsRed[A any, B any](t Tree[A, B]) bool {
+	n, ok := t.(Node[A, B])
+	return ok && n.Color == Red
+}
+
+func blacken[A any, B any](t Tree[A, B]) Tree[A, B] {
+	if n, ok := t.(Node[A, B]); ok && n.Color == Red {
+		n.Color = Black
+		return n
+	}
+	return t
+}
+
+func redden[A any, B any](t Tree[A, B]) Tree[A, B] {
+	if n, ok := t.(Node[A, B]); ok {
+		n.Color = Red
+		return n
+	}
+	return t
+}
+
+func Singleton[A any, B any](key A, value B) Tree[A, B] {
+	return Node[A, B]{Color: Black, Key: key, Value: value, Left: Leaf[A, B]{}, Right: Leaf[A, B]{}}
+}
+
+func Get[A any, B any](cmp nub.Comparator[A], key A, t Tree[A, B]) (B, bool) {
+	for {
+		n, ok := t.(Node[A, B])
+		if !ok {
+			var zero B
+			return zero, false
+		}
+		switch cmp(key, n.Key) {
+		case nub.LT:
+			t = n.Left
+		case nub.GT:
+			t = n.Right
+		default:
+			return n.Value, true
+		}
+	}
+}
+
+func Size[A any, B any](t Tree[A, B]) int {
+	return sizeHelp[A, B](0, t)
+}
+
+func sizeHelp[A any, B any](n int, t Tree[A, B]) int {
+	if nd, ok := t.(Node[A, B]); ok {
+		return sizeHelp[A, B](sizeHelp[A, B](n+1, nd.Right), nd.Left)
+	}
+	return n
+}
+
+// ORDER
+
+func Min[A any, B any](t Tree[A, B]) (Node[A, B], bool) {
+	n, ok := getMin[A, B](t).(Node[A, B])
+	return n, ok
+}
+
+func Max[A any, B any](t Tree[A, B]) (Node[A, B], bool) {
+	for {
+		n, ok := t.(Node[A, B])
+		if !ok || !n.Right.IsNode() {
+			return n, ok
+		}
+		t = n.Right
+	}
+}
+
+// Find the node with the greatest key less than or equal to the given key.
+func Floor[A any, B any](cmp nub.Comparator[A], key A, t Tree[A, B]) (Node[A, B], bool) {
+	var best Node[A, B]
+	found := false
+	for {
+		n, ok := t.(Node[A, B])
+		if !ok {
+			return best, found
+		}
+		switch cmp(key, n.Key) {
+		case nub.LT:
+			t = n.Left
+		case nub.GT:
+			best, found = n, true
+			t = n.Right
+		default:
+			return n, true
+		}
+	}
+}
+
+// Find the node with the least key greater than or equal to the given key.
+func Ceiling[A any, B any](cmp nub.Comparator[A], key A, t Tree[A, B]) (Node[A, B], bool) {
+	var best Node[A, B]
+	found := false
+	for {
+		n, ok := t.(Node[A, B])
+		if !ok {
+			return best, found
+		}
+		switch cmp(key, n.Key) {
+		case nub.LT:
+			best, found = n, true
+			t = n.Left
+		case nub.GT:
+			t = n.Right
+		default:
+			return n, true
+		}
+	}
+}
+
+// Fold from lowest to highest key over the keys between two bounds. The
+// bounds are monotone tests: aboveLo holds from the lower bound upwards and
+// belowHi holds up to the upper bound, so subtrees outside the range are
+// never visited.
+func FoldLBetween[A any, B any, C any](aboveLo func(A) bool, belowHi func(A) bool, fn func(A, B, C) C, acc C, t Tree[A, B]) C {
+	n, ok := t.(Node[A, B])
+	if !ok {
+		return acc
+	}
+	lo, hi := aboveLo(n.Key), belowHi(n.Key)
+	if lo {
+		acc = FoldLBetween(aboveLo, belowHi, fn, acc, n.Left)
+	}
+	if lo && hi {
+		acc = fn(n.Key, n.Value, acc)
+	}
+	if hi {
+		acc = FoldLBetween(aboveLo, belowHi, fn, acc, n.Right)
+	}
+	return acc
+}
+
+// Like FoldLBetween, from highest to lowest key.
+func FoldRBetween[A any, B any, C any](aboveLo func(A) bool, belowHi func(A) bool, fn func(A, B, C) C, acc C, t Tree[A, B]) C {
+	n, ok := t.(Node[A, B])
+	if !ok {
+		return acc
+	}
+	lo, hi := aboveLo(n.Key), belowHi(n.Key)
+	if hi {
+		acc = FoldRBetween(aboveLo, belowHi, fn, acc, n.Right)
+	}
+	if lo && hi {
+		acc = fn(n.Key, n.Value, acc)
+	}
+	if lo {
+		acc = FoldRBetween(aboveLo, belowHi, fn, acc, n.Left)
+	}
+	return acc
+}
+
+// INSERT
+
+// Insert a key-value pair. On a collision the value is replaced and the stored
+// key is kept, as in Elm.
+func Insert[A any, B any](cmp nub.Comparator[A], key A, value B, t Tree[A, B]) Tree[A, B] {
+	return blacken[A, B](insertHelp[A, B](cmp, false, key, value, t))
+}
+
+// Insert a key-value pair. On a collision both the stored key and the value are
+// replaced, for comparators that treat distinct keys as equal.
+func Replace[A any, B any](cmp nub.Comparator[A], key A, value B, t Tree[A, B]) Tree[A, B] {
+	return blacken[A, B](insertHelp[A, B](cmp, true, key, value, t))
+}
+
+func insertHelp[A any, B any](cmp nub.Comparator[A], replaceKey bool, key A, value B, t Tree[A, B]) Tree[A, B] {
+	n, ok := t.(Node[A, B])
+	if !ok {
+		return Node[A, B]{Color: Red, Key: key, Value: value, Left: Leaf[A, B]{}, Right: Leaf[A, B]{}}
+	}
+	switch cmp(key, n.Key) {
+	case nub.LT:
+		return balance[A, B](n.Color, n.Key, n.Value, insertHelp[A, B](cmp, replaceKey, key, value, n.Left), n.Right)
+	case nub.GT:
+		return balance[A, B](n.Color, n.Key, n.Value, n.Left, insertHelp[A, B](cmp, replaceKey, key, value, n.Right))
+	}
+	if replaceKey {
+		n.Key = key
+	}
+	n.Value = value
+	return n
+}
+
+// Restore the red-black invariants after an insertion or removal. Red links
+// are kept leaning left, as in a left-leaning red-black tree.
+func balance[A any, B any](c Color, key A, value B, left Tree[A, B], right Tree[A, B]) Tree[A, B] {
+	if r, ok := right.(Node[A, B]); ok && r.Color == Red {
+		if l, ok := left.(Node[A, B]); ok && l.Color == Red {
+			l.Color = Black
+			r.Color = Black
+			return Node[A, B]{Color: Red, Key: key, Value: value, Left: l, Right: r}
+		}
+		return Node[A, B]{
+			Color: c,
+			Key:   r.Key,
+			Value: r.Value,
+			Left:  Node[A, B]{Color: Red, Key: key, Value: value, Left: left, Right: r.Left},
+			Right: r.Right,
+		}
+	}
+	if l, ok := left.(Node[A, B]); ok && l.Color == Red {
+		if ll, ok := l.Left.(Node[A, B]); ok && ll.Color == Red {
+			ll.Color = Black
+			return Node[A, B]{
+				Color: Red,
+				Key:   l.Key,
+				Value: l.Value,
+				Left:  ll,
+				Right: Node[A, B]{Color: Black, Key: key, Value: value, Left: l.Right, Right: right},
+			}
+		}
+	}
+	return Node[A, B]{Color: c, Key: key, Value: value, Left: left, Right: right}
+}
+
+// REMOVE
+
+func Remove[A any, B any](cmp nub.Comparator[A], key A, t Tree[A, B]) Tree[A, B] {
+	return blacken[A, B](removeHelp[A, B](cmp, key, t))
+}
+
+func removeHelp[A any, B any](cmp nub.Comparator[A], key A, t Tree[A, B]) Tree[A, B] {
+	n, ok := t.(Node[A, B])
+	if !ok {
+		return t
+	}
+	if cmp(key, n.Key) == nub.LT {
+		if l, ok := n.Left.(Node[A, B]); ok && l.Color == Black && !IsRed[A, B](l.Left) {
+			if m, ok := moveRedLeft[A, B](n).(Node[A, B]); ok {
+				return balance[A, B](m.Color, m.Key, m.Value, removeHelp[A, B](cmp, key, m.Left), m.Right)
+			}
+			return Leaf[A, B]{}
+		}
+		n.Left = removeHelp[A, B](cmp, key, n.Left)
+		return n
+	}
+	return removeHelpEQGT[A, B](cmp, key, removeHelpPrepEQGT[A, B](n))
+}
+
+func removeHelpPrepEQGT[A any, B any](n Node[A, B]) Tree[A, B] {
+	if l, ok := n.Left.(Node[A, B]); ok && l.Color == Red {
+		return Node[A, B]{
+			Color: n.Color,
+			Key:   l.Key,
+			Value: l.Value,
+			Left:  l.Left,
+			Right: Node[A, B]{Color: Red, Key: n.Key, Value: n.Value, Left: l.Right, Right: n.Right},
+		}
+	}
+	if r, ok := n.Right.(Node[A, B]); ok && r.Color == Black && !IsRed[A, B](r.Left) {
+		return moveRedRight[A, B](n)
+	}
+	return n
+}
+
+func removeHelpEQGT[A any, B any](cmp nub.Comparator[A], key A, t Tree[A, B]) Tree[A, B] {
+	n, ok := t.(Node[A, B])
+	if !ok {
+		return t
+	}
+	if cmp(key, n.Key) == nub.EQ {
+		if m, ok := getMin[A, B](n.Right).(Node[A, B]); ok {
+			return balance[A, B](n.Color, m.Key, m.Value, n.Left, removeMin[A, B](n.Right))
+		}
+		return Leaf[A, B]{}
+	}
+	return balance[A, B](n.Color, n.Key, n.Value, n.Left, removeHelp[A, B](cmp, key, n.Right))
+}
+
+func getMin[A any, B any](t Tree[A, B]) Tree[A, B] {
+	for {
+		n, ok := t.(Node[A, B])
+		if !ok || !n.Left.IsNode() {
+			return t
+		}
+		t = n.Left
+	}
+}
+
+func removeMin[A any, B any](t Tree[A, B]) Tree[A, B] {
+	n, ok := t.(Node[A, B])
+	if !ok {
+		return t
+	}
+	l, ok := n.Left.(Node[A, B])
+	if !ok {
+		return Leaf[A, B]{}
+	}
+	if l.Color == Black && !IsRed[A, B](l.Left) {
+		if m, ok := moveRedLeft[A, B](n).(Node[A, B]); ok {
+			return balance[A, B](m.Color, m.Key, m.Value, removeMin[A, B](m.Left), m.Right)
+		}
+		return Leaf[A, B]{}
+	}
+	n.Left = removeMin[A, B](n.Left)
+	return n
+}
+
+func moveRedLeft[A any, B any](n Node[A, B]) Tree[A, B] {
+	l, lok := n.Left.(Node[A, B])
+	r, rok := n.Right.(Node[A, B])
+	if !lok || !rok {
+		return n
+	}
+	if rl, ok := r.Left.(Node[A, B]); ok && rl.Color == Red {
+		return Node[A, B]{
+			Color: Red,
+			Key:   rl.Key,
+			Value: rl.Value,
+			Left:  Node[A, B]{Color: Black, Key: n.Key, Value: n.Value, Left: redden[A, B](l), Right: rl.Left},
+			Right: Node[A, B]{Color: Black, Key: r.Key, Value: r.Value, Left: rl.Right, Right: r.Right},
+		}
+	}
+	return Node[A, B]{Color: Black, Key: n.Key, Value: n.Value, Left: redden[A, B](l), Right: redden[A, B](r)}
+}
+
+func moveRedRight[A any, B any](n Node[A, B]) Tree[A, B] {
+	l, lok := n.Left.(Node[A, B])
+	r, rok := n.Right.(Node[A, B])
+	if !lok || !rok {
+		return n
+	}
+	if ll, ok := l.Left.(Node[A, B]); ok && ll.Color == Red {
+		return Node[A, B]{
+			Color: Red,
+			Key:   l.Key,
+			Value: l.Value,
+			Left:  blacken[A, B](ll),
+			Right: Node[A, B]{Color: Black, Key: n.Key, Value: n.Value, Left: l.Right, Right: redden[A, B](r)},
+		}
+	}
+	return Node[A, B]{Color: Black, Key: n.Key, Value: n.Value, Left: redden[A, B](l), Right: redden[A, B](r)}
+}
+
+// TRANSFORM
+
+func Map[A any, B, C any](fn func(A, B) C, t Tree[A, B]) Tree[A, C] {
+	if n, ok := t.(Node[A, B]); ok {
+		return Node[A, C]{
+			Color: n.Color,
+			Key:   n.Key,
+			Value: fn(n.Key, n.Value),
+			Left:  Map[A, B](fn, n.Left),
+			Right: Map[A, B](fn, n.Right),
+		}
+	}
+	return Leaf[A, C]{}
+}
+
+func FoldL[A any, B any, C any](fn func(A, B, C) C, acc C, t Tree[A, B]) C {
+	if n, ok := t.(Node[A, B]); ok {
+		return FoldL[A, B](fn, fn(n.Key, n.Value, FoldL[A, B](fn, acc, n.Left)), n.Right)
+	}
+	return acc
+}
+
+func FoldR[A any, B any, C any](fn func(A, B, C) C, acc C, t Tree[A, B]) C {
+	if n, ok := t.(Node[A, B]); ok {
+		return FoldR[A, B](fn, fn(n.Key, n.Value, FoldR[A, B](fn, acc, n.Right)), n.Left)
+	}
+	return acc
+}
+
+// Visit the key-value pairs in key order until yield returns false. Reports
+// whether every pair was visited.
+func All[A any, B any](yield func(A, B) bool, t Tree[A, B]) bool {
+	if n, ok := t.(Node[A, B]); ok {
+		return All(yield, n.Left) && yield(n.Key, n.Value) && All(yield, n.Right)
+	}
+	return true
+}
+
+// Determine if two trees hold the same keys with equal values, regardless of
+// their shape.
+func Equal[A any, B any](cmp nub.Comparator[A], eq func(B, B) bool, x Tree[A, B], y Tree[A, B]) bool {
+	if Size[A, B](x) != Size[A, B](y) {
+		return false
+	}
+	next, stop := iter.Pull2(func(yield func(A, B) bool) {
+		All(yield, y)
+	})
+	defer stop()
+	return All(func(key A, value B) bool {
+		k, v, ok := next()
+		return ok && cmp(key, k) == nub.EQ && eq(value, v)
+	}, x)
+}
+
+// Apply a function that may fail to every value, in key order. Stops at the
+// first failure.
+func Traverse[A any, B, C any](fn func(B) (C, bool), t Tree[A, B]) (Tree[A, C], bool) {
+	n, ok := t.(Node[A, B])
+	if !ok {
+		return Leaf[A, C]{}, true
+	}
+	left, ok := Traverse[A, B](fn, n.Left)
+	if !ok {
+		return nil, false
+	}
+	value, ok := fn(n.Value)
+	if !ok {
+		return nil, false
+	}
+	right, ok := Traverse[A, B](fn, n.Right)
+	if !ok {
+		return nil, false
+	}
+	return Node[A, C]{Color: n.Color, Key: n.Key, Value: value, Left: left, Right: right}, true
+}
+
+// COMBINE
+
+// Walk the keys of both trees from lowest to highest, calling the matching
+// accumulator for keys found only on the left, in both, or only on the right.
+func Merge[A any, B, C, D any](cmp nub.Comparator[A], insertLeft func(A, B, D) D, insertBoth func(A, B, C, D) D, insertRight func(A, C, D) D, left Tree[A, B], right Tree[A, C], result D) D {
+	type entry struct {
+		key   A
+		value B
+	}
+
+	var pending []entry
+	All(func(key A, value B) bool {
+		pending = append(pending, entry{key, value})
+		return true
+	}, left)
+
+	result = FoldL(func(rKey A, rVal C, acc D) D {
+		for len(pending) > 0 {
+			l := pending[0]
+			switch cmp(l.key, rKey) {
+			case nub.LT:
+				acc = insertLeft(l.key, l.value, acc)
+				pending = pending[1:]
+				continue
+			case nub.EQ:
+				pending = pending[1:]
+				return insertBoth(l.key, l.value, rVal, acc)
+			}
+			break
+		}
+		return insertRight(rKey, rVal, acc)
+	}, result, right)
+
+	for _, l := range pending {
+		result = insertLeft(l.key, l.value, result)
+	}
+	return result
+}
@@ -0,0 +1,280 @@
+package intervalmap
+
+import (
+	"fmt"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/tuple"
+	"github.com/obiloud/curry-go/util"
+)
+
+// A closed range of keys, from Lo to Hi inclusive.
+type Interval[A nub.Ord] struct {
+	Lo A
+	Hi A
+}
+
+func (i Interval[A]) String() string {
+	return fmt.Sprintf("[%s, %s]", util.Stringify(i.Lo), util.Stringify(i.Hi))
+}
+
+// Intervals are ordered by their lower bound, then by their upper bound.
+func compareIntervals[A nub.Ord](x Interval[A], y Interval[A]) nub.Order {
+	if o := nub.Compare(x.Lo, y.Lo); o != nub.EQ {
+		return o
+	}
+	return nub.Compare(x.Hi, y.Hi)
+}
+
+// A map from intervals to values. Intervals may overlap; inserting the same
+// interval twice replaces its value.
+//
+// Maps are persistent AVL trees ordered by interval, where every node also
+// records the highest upper bound in its subtree. Insert runs in O(log n), and
+// Stabbing and Overlapping run in O(log n + k) for k results.
+type IntervalMap[A nub.Ord, B any] struct {
+	root *node[A, B]
+	size int
+}
+
+type node[A nub.Ord, B any] struct {
+	interval Interval[A]
+	value    B
+	maxHi    A
+	height   int
+	left     *node[A, B]
+	right    *node[A, B]
+}
+
+func height[A nub.Ord, B any](n *node[A, B]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// Build a node, computing its height and the highest upper bound below it.
+func mk[A nub.Ord, B any](interval Interval[A], value B, left *node[A, B], right *node[A, B]) *node[A, B] {
+	maxHi := interval.Hi
+	if left != nil {
+		maxHi = nub.Max(maxHi, left.maxHi)
+	}
+	if right != nil {
+		maxHi = nub.Max(maxHi, right.maxHi)
+	}
+	return &node[A, B]{
+		interval: interval,
+		value:    value,
+		maxHi:    maxHi,
+		height:   max(height(left), height(right)) + 1,
+		left:     left,
+		right:    right,
+	}
+}
+
+// Restore the AVL invariant after one side grew by at most one level.
+func rebalance[A nub.Ord, B any](interval Interval[A], value B, left *node[A, B], right *node[A, B]) *node[A, B] {
+	switch diff := height(left) - height(right); {
+	case diff > 1:
+		if height(left.left) < height(left.right) {
+			lr := left.right
+			left = mk(lr.interval, lr.value, mk(left.interval, left.value, left.left, lr.left), lr.right)
+		}
+		return mk(left.interval, left.value, left.left, mk(interval, value, left.right, right))
+	case diff < -1:
+		if height(right.right) < height(right.left) {
+			rl := right.left
+			right = mk(rl.interval, rl.value, rl.left, mk(right.interval, right.value, rl.right, right.right))
+		}
+		return mk(right.interval, right.value, mk(interval, value, left, right.left), right.right)
+	}
+	return mk(interval, value, left, right)
+}
+
+// Convert an interval map into a string.
+func (m IntervalMap[A, B]) String() string {
+	return ToList(m).String()
+}
+
+// Create an empty interval map.
+func Empty[A nub.Ord, B any]() IntervalMap[A, B] {
+	return IntervalMap[A, B]{}
+}
+
+// Determine if an interval map is empty.
+func IsEmpty[A nub.Ord, B any](m IntervalMap[A, B]) bool {
+	return m.root == nil
+}
+
+// Determine the number of intervals in the map.
+func Size[A nub.Ord, B any](m IntervalMap[A, B]) int {
+	return m.size
+}
+
+// Map the interval from lo to hi, inclusive, to a value. Replaces the value
+// when the same interval is already present. An interval with lo greater
+// than hi is empty and is not inserted.
+func Insert[A nub.Ord, B any](lo A, hi A, value B, m IntervalMap[A, B]) IntervalMap[A, B] {
+	if lo > hi {
+		return m
+	}
+	root, added := insertHelp(Interval[A]{Lo: lo, Hi: hi}, value, m.root)
+	if added {
+		return IntervalMap[A, B]{root: root, size: m.size + 1}
+	}
+	return IntervalMap[A, B]{root: root, size: m.size}
+}
+
+func insertHelp[A nub.Ord, B any](interval Interval[A], value B, n *node[A, B]) (*node[A, B], bool) {
+	if n == nil {
+		return mk[A, B](interval, value, nil, nil), true
+	}
+	switch compareIntervals(interval, n.interval) {
+	case nub.LT:
+		left, added := insertHelp(interval, value, n.left)
+		return rebalance(n.interval, n.value, left, n.right), added
+	case nub.GT:
+		right, added := insertHelp(interval, value, n.right)
+		return rebalance(n.interval, n.value, n.left, right), added
+	}
+	return mk(interval, value, n.left, n.right), false
+}
+
+// Get the value of exactly the interval from lo to hi.
+func Get[A nub.Ord, B any](lo A, hi A, m IntervalMap[A, B]) maybe.Maybe[B] {
+	interval := Interval[A]{Lo: lo, Hi: hi}
+	for n := m.root; n != nil; {
+		switch compareIntervals(interval, n.interval) {
+		case nub.LT:
+			n = n.left
+		case nub.GT:
+			n = n.right
+		default:
+			return maybe.Just(n.value)
+		}
+	}
+	return maybe.Nothing[B]()
+}
+
+// QUERY
+
+// Find the intervals that contain a point, ordered by interval.
+func Stabbing[A nub.Ord, B any](point A, m IntervalMap[A, B]) list.List[tuple.Tuple[Interval[A], B]] {
+	return Overlapping(point, point, m)
+}
+
+// Find the intervals that share at least one point with the interval from lo
+// to hi, ordered by interval.
+func Overlapping[A nub.Ord, B any](lo A, hi A, m IntervalMap[A, B]) list.List[tuple.Tuple[Interval[A], B]] {
+	var found []tuple.Tuple[Interval[A], B]
+	overlappingHelp(lo, hi, m.root, &found)
+	return list.FromSlice(found)
+}
+
+func overlappingHelp[A nub.Ord, B any](lo A, hi A, n *node[A, B], found *[]tuple.Tuple[Interval[A], B]) {
+	// Nothing below ends at or after lo.
+	if n == nil || n.maxHi < lo {
+		return
+	}
+	overlappingHelp(lo, hi, n.left, found)
+	// This interval and everything to its right start after hi.
+	if n.interval.Lo > hi {
+		return
+	}
+	if n.interval.Hi >= lo {
+		*found = append(*found, tuple.Pair(n.interval, n.value))
+	}
+	overlappingHelp(lo, hi, n.right, found)
+}
+
+// TRANSFORM
+
+// Fold over the intervals and their values, ordered by interval.
+func FoldL[A nub.Ord, B any, C any](fn func(Interval[A], B, C) C, acc C, m IntervalMap[A, B]) C {
+	return foldLHelp(fn, acc, m.root)
+}
+
+func foldLHelp[A nub.Ord, B any, C any](fn func(Interval[A], B, C) C, acc C, n *node[A, B]) C {
+	if n == nil {
+		return acc
+	}
+	return foldLHelp(fn, fn(n.interval, n.value, foldLHelp(fn, acc, n.left)), n.right)
+}
+
+// Merge intervals that overlap or touch and carry equal values, according to
+// the given equality. Intervals with different values are left as they are,
+// even when they overlap. A merge that would produce the same interval as
+// another run is skipped, so no value is ever lost.
+//
+// Each interval is only compared with the runs that are still open at its
+// lower bound, so disjoint intervals coalesce in O(n log n).
+func Coalesce[A nub.Ord, B any](eq func(B, B) bool, m IntervalMap[A, B]) IntervalMap[A, B] {
+	type run struct {
+		interval Interval[A]
+		value    B
+	}
+
+	var runs []run
+	var open []int
+	// The intervals of all runs so far; the map keeps one value per interval.
+	taken := map[Interval[A]]bool{}
+	FoldL(func(interval Interval[A], value B, _ struct{}) struct{} {
+		// Intervals arrive by lower bound, so a run that ends before this
+		// interval starts can never be extended again.
+		kept := open[:0]
+		for _, i := range open {
+			if runs[i].interval.Hi >= interval.Lo {
+				kept = append(kept, i)
+			}
+		}
+		open = kept
+
+		for _, i := range open {
+			if !eq(runs[i].value, value) {
+				continue
+			}
+			extended := Interval[A]{Lo: runs[i].interval.Lo, Hi: nub.Max(runs[i].interval.Hi, interval.Hi)}
+			if extended == runs[i].interval {
+				return struct{}{}
+			}
+			if taken[extended] {
+				continue
+			}
+			delete(taken, runs[i].interval)
+			taken[extended] = true
+			runs[i].interval = extended
+			return struct{}{}
+		}
+		open = append(open, len(runs))
+		runs = append(runs, run{interval, value})
+		taken[interval] = true
+		return struct{}{}
+	}, struct{}{}, m)
+
+	result := Empty[A, B]()
+	for _, r := range runs {
+		result = Insert(r.interval.Lo, r.interval.Hi, r.value, result)
+	}
+	return result
+}
+
+// LISTS
+
+// Convert a list of intervals and values into an interval map. If an interval
+// appears more than once, the last value wins.
+func FromList[A nub.Ord, B any](ls list.List[tuple.Tuple[Interval[A], B]]) IntervalMap[A, B] {
+	return list.FoldL(func(pair tuple.Tuple[Interval[A], B], acc IntervalMap[A, B]) IntervalMap[A, B] {
+		interval := tuple.First(pair)
+		return Insert(interval.Lo, interval.Hi, tuple.Second(pair), acc)
+	}, Empty[A, B](), ls)
+}
+
+// Convert an interval map into a list of intervals and values, ordered by
+// interval.
+func ToList[A nub.Ord, B any](m IntervalMap[A, B]) list.List[tuple.Tuple[Interval[A], B]] {
+	return list.Reverse[tuple.Tuple[Interval[A], B]](FoldL(func(interval Interval[A], value B, acc list.List[tuple.Tuple[Interval[A], B]]) list.List[tuple.Tuple[Interval[A], B]] {
+		return list.Cons(tuple.Pair(interval, value), acc)
+	}, list.Nil[tuple.Tuple[Interval[A], B]](), m))
+}
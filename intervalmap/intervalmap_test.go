@@ -0,0 +1,151 @@
+package intervalmap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+func intervals[A nub.Ord, B any](ls list.List[tuple.Tuple[Interval[A], B]]) []Interval[A] {
+	return list.ToSlice[Interval[A]](list.Map(tuple.First[Interval[A], B], ls))
+}
+
+var tiers = Insert(0, 9, "basic", Insert(10, 99, "silver", Insert(100, 999, "gold", Insert(50, 150, "promo", Empty[int, string]()))))
+
+func TestInsert(t *testing.T) {
+	if Size(tiers) != 4 || IsEmpty(tiers) || !IsEmpty(Empty[int, string]()) {
+		t.Error("Size / IsEmpty")
+	}
+
+	if Get(10, 99, tiers) != maybe.Just("silver") || Get(10, 98, tiers) != maybe.Nothing[string]() {
+		t.Error("Get matches exact intervals")
+	}
+
+	replaced := Insert(10, 99, "silver+", tiers)
+	if Size(replaced) != 4 || Get(10, 99, replaced) != maybe.Just("silver+") {
+		t.Error("Insert replaces the value of the same interval")
+	}
+
+	if Insert(5, 1, "empty", tiers) != tiers {
+		t.Error("Insert ignores empty intervals")
+	}
+
+	if tiers.String() != `[([0, 9], "basic"), ([10, 99], "silver"), ([50, 150], "promo"), ([100, 999], "gold")]` {
+		t.Errorf("ToList is ordered by interval, got %s", tiers)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	if Stabbing(120, tiers) != list.FromSlice([]tuple.Tuple[Interval[int], string]{
+		tuple.Pair(Interval[int]{50, 150}, "promo"),
+		tuple.Pair(Interval[int]{100, 999}, "gold"),
+	}) {
+		t.Error("Stabbing finds every interval containing the point")
+	}
+
+	if !slices.Equal(intervals[int, string](Stabbing(9, tiers)), []Interval[int]{{0, 9}}) || !list.IsEmpty[tuple.Tuple[Interval[int], string]](Stabbing(1000, tiers)) {
+		t.Error("Stabbing includes both bounds")
+	}
+
+	if !slices.Equal(intervals[int, string](Overlapping(95, 100, tiers)), []Interval[int]{{10, 99}, {50, 150}, {100, 999}}) {
+		t.Error("Overlapping")
+	}
+
+	if !list.IsEmpty[tuple.Tuple[Interval[int], string]](Overlapping(-5, -1, tiers)) {
+		t.Error("Overlapping nothing")
+	}
+}
+
+func TestAgainstScan(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	m := Empty[int, int]()
+	var all []Interval[int]
+	for i := 0; i < 2000; i++ {
+		lo := r.Intn(10000)
+		iv := Interval[int]{lo, lo + r.Intn(300)}
+		if Get(iv.Lo, iv.Hi, m).IsNothing() {
+			all = append(all, iv)
+		}
+		m = Insert(iv.Lo, iv.Hi, i, m)
+	}
+
+	if Size(m) != len(all) {
+		t.Fatalf("Size %d, want %d", Size(m), len(all))
+	}
+	if m.root.height > 16 {
+		t.Errorf("tree is unbalanced: height %d", m.root.height)
+	}
+
+	slices.SortFunc(all, func(x Interval[int], y Interval[int]) int {
+		return compareIntervals(x, y).Int()
+	})
+	for q := 0; q < 200; q++ {
+		lo := r.Intn(10500) - 250
+		hi := lo + r.Intn(100)
+		var want []Interval[int]
+		for _, iv := range all {
+			if iv.Lo <= hi && iv.Hi >= lo {
+				want = append(want, iv)
+			}
+		}
+		if got := intervals[int, int](Overlapping(lo, hi, m)); !slices.Equal(got, want) {
+			t.Fatalf("Overlapping(%d, %d): got %v, want %v", lo, hi, got, want)
+		}
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	windows := FromList[int, string](list.FromSlice([]tuple.Tuple[Interval[int], string]{
+		tuple.Pair(Interval[int]{1, 3}, "down"),
+		tuple.Pair(Interval[int]{2, 5}, "down"),
+		tuple.Pair(Interval[int]{4, 6}, "slow"),
+		tuple.Pair(Interval[int]{5, 8}, "down"),
+		tuple.Pair(Interval[int]{10, 12}, "down"),
+		tuple.Pair(Interval[int]{12, 14}, "down"),
+	}))
+
+	eq := func(a string, b string) bool { return a == b }
+	expected := `[([1, 8], "down"), ([4, 6], "slow"), ([10, 14], "down")]`
+	if Coalesce(eq, windows).String() != expected {
+		t.Errorf("Coalesce merges overlapping and touching runs, got %s", Coalesce(eq, windows))
+	}
+
+	colliding := FromList[int, string](list.FromSlice([]tuple.Tuple[Interval[int], string]{
+		tuple.Pair(Interval[int]{1, 3}, "v"),
+		tuple.Pair(Interval[int]{1, 5}, "w"),
+		tuple.Pair(Interval[int]{2, 5}, "v"),
+	}))
+	if Coalesce(eq, colliding).String() != `[([1, 3], "v"), ([1, 5], "w"), ([2, 5], "v")]` {
+		t.Errorf("Coalesce keeps runs that would collide with another interval, got %s", Coalesce(eq, colliding))
+	}
+
+	count := FoldL(func(_ Interval[int], _ string, acc int) int { return acc + 1 }, 0, windows)
+	if count != 6 {
+		t.Error("FoldL")
+	}
+}
+
+func TestCoalesceLarge(t *testing.T) {
+	n := 200000
+	chained := Empty[int, string]()
+	disjoint := Empty[int, int]()
+	for i := 0; i < n; i++ {
+		chained = Insert(i, i+1, "up", chained)
+		disjoint = Insert(2*i, 2*i, i%3, disjoint)
+	}
+
+	eqString := func(a string, b string) bool { return a == b }
+	if Coalesce(eqString, chained).String() != `[([0, 200000], "up")]` {
+		t.Error("touching intervals with equal values coalesce into one")
+	}
+
+	eqInt := func(a int, b int) bool { return a == b }
+	if Size(Coalesce(eqInt, disjoint)) != n {
+		t.Error("disjoint intervals are kept")
+	}
+}
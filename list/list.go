@@ -1,7 +1,9 @@
 package list
 
 import (
+	"encoding/json"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/obiloud/curry-go/maybe"
@@ -43,6 +45,64 @@ func (c consList[T]) String() string {
 	return fmt.Sprintf("[%s]", strings.Join([]string(slice), ", "))
 }
 
+func (n nilList[T]) MarshalJSON() ([]byte, error) {
+	return []byte("[]"), nil
+}
+
+func (c consList[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ToSlice[T](c))
+}
+
+// Decode a JSON array into a list.
+//
+// List is an interface, so `json.Unmarshal` cannot decode into it when it is a
+// struct field, a map or dict value, or a tuple element. Use JSON in those
+// places instead.
+func FromJSON[T any](data []byte) (List[T], error) {
+	var slice []T
+	if err := json.Unmarshal(data, &slice); err != nil {
+		return Nil[T](), err
+	}
+	return FromSlice(slice), nil
+}
+
+// A list that `json.Unmarshal` can decode, for struct fields and other values
+// nested inside JSON. It encodes the same way as the list it holds, and its
+// zero value is an empty list.
+type JSON[T any] struct {
+	list List[T]
+}
+
+// Wrap a list so it can be encoded and decoded as part of a larger value.
+func AsJSON[T any](ls List[T]) JSON[T] {
+	return JSON[T]{list: ls}
+}
+
+// The wrapped list.
+func (j JSON[T]) List() List[T] {
+	if j.list == nil {
+		return Nil[T]()
+	}
+	return j.list
+}
+
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.List())
+}
+
+// Decoding `null` leaves the list unchanged, as encoding/json expects.
+func (j *JSON[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	ls, err := FromJSON[T](data)
+	if err != nil {
+		return err
+	}
+	j.list = ls
+	return nil
+}
+
 // CREATE
 
 func Nil[T any]() List[T] {
@@ -65,11 +125,10 @@ func Range(lo int, hi int) List[int] {
 }
 
 func rangeHelp(lo int, hi int, list List[int]) List[int] {
-	if lo <= hi {
-		return rangeHelp(lo, hi-1, Cons(hi, list))
-	} else {
-		return list
+	for ; lo <= hi; hi-- {
+		list = Cons(hi, list)
 	}
+	return list
 }
 
 func Repeat[T any](n int, value T) List[T] {
@@ -77,19 +136,42 @@ func Repeat[T any](n int, value T) List[T] {
 }
 
 func repeatHelp[T any](result List[T], n int, value T) List[T] {
-	if n <= 0 {
-		return result
+	for ; n > 0; n-- {
+		result = Cons(value, result)
 	}
-	return repeatHelp(Cons(value, result), n-1, value)
+	return result
+}
+
+func Unfoldr[S, T any](fn func(S) maybe.Maybe[tuple.Tuple[T, S]], seed S) List[T] {
+	acc := Nil[T]()
+	for {
+		next, ok := maybe.Get[tuple.Tuple[T, S]](fn(seed))
+		if !ok {
+			return Reverse[T](acc)
+		}
+		acc = Cons(tuple.First(next), acc)
+		seed = tuple.Second(next)
+	}
+}
+
+func Iterate[T any](n int, fn func(T) T, seed T) List[T] {
+	acc := Nil[T]()
+	for ; n > 0; n-- {
+		acc = Cons(seed, acc)
+		seed = fn(seed)
+	}
+	return Reverse[T](acc)
 }
 
 // UTILITIES
 
 func Length[T any](list List[T]) int {
-	if list.isCons() {
-		return 1 + Length[T](list.(consList[T]).tail)
+	n := 0
+	for list.isCons() {
+		n++
+		list = list.(consList[T]).tail
 	}
-	return 0
+	return n
 }
 
 func Reverse[T any](list List[T]) List[T] {
@@ -105,10 +187,30 @@ func FromSlice[T any](slice []T) List[T] {
 }
 
 func ToSlice[T any](list List[T]) []T {
-	con := func(h T, acc []T) []T {
-		return append([]T{h}, acc...)
+	slice := []T{}
+	for list.isCons() {
+		slice = append(slice, list.(consList[T]).head)
+		list = list.(consList[T]).tail
+	}
+	return slice
+}
+
+func Values[T any](ls List[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for xs := ls; xs.isCons(); xs = xs.(consList[T]).tail {
+			if !yield(xs.(consList[T]).head) {
+				return
+			}
+		}
 	}
-	return FoldR(con, []T{}, list)
+}
+
+func FromSeq[T any](seq iter.Seq[T]) List[T] {
+	slice := []T{}
+	for x := range seq {
+		slice = append(slice, x)
+	}
+	return FromSlice(slice)
 }
 
 func Member[T comparable](x T, list List[T]) bool {
@@ -121,18 +223,16 @@ func All[T any](isOK func(T) bool, ls List[T]) bool {
 }
 
 func Any[T any](isOK func(T) bool, ls List[T]) bool {
-	switch ls.(type) {
-	case nilList[T]:
-		return false
-	}
-	if isOK(ls.(consList[T]).head) {
-		return true
-	} else {
-		return Any(isOK, ls.(consList[T]).tail)
+	for ls.isCons() {
+		if isOK(ls.(consList[T]).head) {
+			return true
+		}
+		ls = ls.(consList[T]).tail
 	}
+	return false
 }
 
-func Maximum[T nub.Num](xs List[T]) maybe.Maybe[T] {
+func Maximum[T nub.Ord](xs List[T]) maybe.Maybe[T] {
 	switch xs.(type) {
 	case nilList[T]:
 		return maybe.Nothing[T]()
@@ -140,7 +240,7 @@ func Maximum[T nub.Num](xs List[T]) maybe.Maybe[T] {
 	return maybe.Just(FoldL(nub.Max[T], xs.(consList[T]).head, xs.(consList[T]).tail))
 }
 
-func Minimum[T nub.Num](xs List[T]) maybe.Maybe[T] {
+func Minimum[T nub.Ord](xs List[T]) maybe.Maybe[T] {
 	switch xs.(type) {
 	case nilList[T]:
 		return maybe.Nothing[T]()
@@ -148,6 +248,45 @@ func Minimum[T nub.Num](xs List[T]) maybe.Maybe[T] {
 	return maybe.Just(FoldL(nub.Min[T], xs.(consList[T]).head, xs.(consList[T]).tail))
 }
 
+// On ties the By and With variants return the element that comes first.
+
+func MaximumBy[A any, B nub.Ord](key func(A) B, xs List[A]) maybe.Maybe[A] {
+	return MaximumWith(func(x A, y A) nub.Order {
+		return nub.Compare(key(x), key(y))
+	}, xs)
+}
+
+func MinimumBy[A any, B nub.Ord](key func(A) B, xs List[A]) maybe.Maybe[A] {
+	return MinimumWith(func(x A, y A) nub.Order {
+		return nub.Compare(key(x), key(y))
+	}, xs)
+}
+
+func MaximumWith[T any](compare func(T, T) nub.Order, xs List[T]) maybe.Maybe[T] {
+	return pickWith(func(x T, best T) bool {
+		return compare(x, best) == nub.GT
+	}, xs)
+}
+
+func MinimumWith[T any](compare func(T, T) nub.Order, xs List[T]) maybe.Maybe[T] {
+	return pickWith(func(x T, best T) bool {
+		return compare(x, best) == nub.LT
+	}, xs)
+}
+
+func pickWith[T any](better func(T, T) bool, xs List[T]) maybe.Maybe[T] {
+	switch xs.(type) {
+	case nilList[T]:
+		return maybe.Nothing[T]()
+	}
+	return maybe.Just(FoldL(func(x T, best T) T {
+		if better(x, best) {
+			return x
+		}
+		return best
+	}, xs.(consList[T]).head, xs.(consList[T]).tail))
+}
+
 func Sum[T nub.Num](xs List[T]) T {
 	add := func(x T, y T) T {
 		return x + y
@@ -176,49 +315,57 @@ func IndexedMap[A, B any](fn func(int, A) B, ls List[A]) List[B] {
 }
 
 func FoldL[A, B any](fn func(A, B) B, acc B, ls List[A]) B {
-	switch ls.(type) {
-	case nilList[A]:
-		return acc
+	for ls.isCons() {
+		acc = fn(ls.(consList[A]).head, acc)
+		ls = ls.(consList[A]).tail
 	}
-	return FoldL(fn, fn(ls.(consList[A]).head, acc), ls.(consList[A]).tail)
+	return acc
 }
 
 func FoldR[A, B any](fn func(A, B) B, acc B, ls List[A]) B {
-	return foldRHelper(fn, acc, 0, ls)
-}
-
-func foldRHelper[A, B any](fn func(A, B) B, acc B, ctr int, a List[A]) B {
-	switch a.(type) {
-	case nilList[A]:
-		return acc
+	slice := ToSlice[A](ls)
+	for i := len(slice) - 1; i >= 0; i-- {
+		acc = fn(slice[i], acc)
 	}
+	return acc
+}
 
-	b := a.(consList[A]).tail
-	switch b.(type) {
-	case nilList[A]:
-		return fn(a.(consList[A]).head, acc)
+func ScanL[A, B any](fn func(A, B) B, acc B, ls List[A]) List[B] {
+	results := Singleton(acc)
+	for x := range Values[A](ls) {
+		acc = fn(x, acc)
+		results = Cons(acc, results)
 	}
+	return Reverse[B](results)
+}
 
-	c := b.(consList[A]).tail
-	switch c.(type) {
-	case nilList[A]:
-		return fn(a.(consList[A]).head, fn(b.(consList[A]).head, acc))
+func ScanR[A, B any](fn func(A, B) B, acc B, ls List[A]) List[B] {
+	results := Singleton(acc)
+	for x := range Values[A](Reverse[A](ls)) {
+		acc = fn(x, acc)
+		results = Cons(acc, results)
 	}
+	return results
+}
 
-	d := c.(consList[A]).tail
-	switch d.(type) {
-	case nilList[A]:
-		return fn(a.(consList[A]).head, fn(b.(consList[A]).head, fn(c.(consList[A]).head, acc)))
+func MapAccumL[A, S, B any](fn func(A, S) tuple.Tuple[S, B], acc S, ls List[A]) tuple.Tuple[S, List[B]] {
+	results := Nil[B]()
+	for x := range Values[A](ls) {
+		step := fn(x, acc)
+		acc = tuple.First(step)
+		results = Cons(tuple.Second(step), results)
 	}
+	return tuple.Pair(acc, Reverse[B](results))
+}
 
-	e := d.(consList[A]).tail
-	var rest B
-	if ctr > 500 {
-		rest = FoldL(fn, acc, Reverse[A](e))
-	} else {
-		rest = foldRHelper(fn, acc, ctr+1, e)
+func MapAccumR[A, S, B any](fn func(A, S) tuple.Tuple[S, B], acc S, ls List[A]) tuple.Tuple[S, List[B]] {
+	results := Nil[B]()
+	for x := range Values[A](Reverse[A](ls)) {
+		step := fn(x, acc)
+		acc = tuple.First(step)
+		results = Cons(tuple.Second(step), results)
 	}
-	return fn(a.(consList[A]).head, fn(b.(consList[A]).head, fn(c.(consList[A]).head, fn(d.(consList[A]).head, rest))))
+	return tuple.Pair(acc, results)
 }
 
 func Filter[T any](fn func(T) bool, ls List[T]) List[T] {
@@ -240,6 +387,22 @@ func FilterMap[A, B any](fn func(A) maybe.Maybe[B], ls List[A]) List[B] {
 	return FoldR(maybeCons, Nil[B](), ls)
 }
 
+func TraverseMaybe[A, B any](fn func(A) maybe.Maybe[B], ls List[A]) maybe.Maybe[List[B]] {
+	slice := []B{}
+	for x := range Values[A](ls) {
+		y, ok := maybe.Get[B](fn(x))
+		if !ok {
+			return maybe.Nothing[List[B]]()
+		}
+		slice = append(slice, y)
+	}
+	return maybe.Just(FromSlice(slice))
+}
+
+func SequenceMaybe[T any](ls List[maybe.Maybe[T]]) maybe.Maybe[List[T]] {
+	return TraverseMaybe(nub.Id[maybe.Maybe[T]], ls)
+}
+
 // COMBINE
 
 func Append[T any](xs List[T], ys List[T]) List[T] {
@@ -274,79 +437,303 @@ func Intersperse[T any](sep T, xs List[T]) List[T] {
 }
 
 func Map2[A, B, C any](fn func(A, B) C, xs List[A], ys List[B]) List[C] {
-	slice1 := ToSlice[A](xs)
-	slice2 := ToSlice[B](ys)
-	results := []C{}
-	for i := 0; i < len(slice1) && i < len(slice2); i++ {
-		a := slice1[i]
-		b := slice2[i]
-		results = append(results, fn(a, b))
+	acc := Nil[C]()
+	for xs.isCons() && ys.isCons() {
+		x, y := xs.(consList[A]), ys.(consList[B])
+		acc = Cons(fn(x.head, y.head), acc)
+		xs, ys = x.tail, y.tail
+	}
+	return Reverse[C](acc)
+}
+
+func Map3[A, B, C, D any](fn func(A, B, C) D, xs List[A], ys List[B], zs List[C]) List[D] {
+	acc := Nil[D]()
+	for xs.isCons() && ys.isCons() && zs.isCons() {
+		x, y, z := xs.(consList[A]), ys.(consList[B]), zs.(consList[C])
+		acc = Cons(fn(x.head, y.head, z.head), acc)
+		xs, ys, zs = x.tail, y.tail, z.tail
+	}
+	return Reverse[D](acc)
+}
+
+func Map4[A, B, C, D, E any](fn func(A, B, C, D) E, ws List[A], xs List[B], ys List[C], zs List[D]) List[E] {
+	acc := Nil[E]()
+	for ws.isCons() && xs.isCons() && ys.isCons() && zs.isCons() {
+		w, x, y, z := ws.(consList[A]), xs.(consList[B]), ys.(consList[C]), zs.(consList[D])
+		acc = Cons(fn(w.head, x.head, y.head, z.head), acc)
+		ws, xs, ys, zs = w.tail, x.tail, y.tail, z.tail
+	}
+	return Reverse[E](acc)
+}
+
+func Map5[A, B, C, D, E, F any](fn func(A, B, C, D, E) F, vs List[A], ws List[B], xs List[C], ys List[D], zs List[E]) List[F] {
+	acc := Nil[F]()
+	for vs.isCons() && ws.isCons() && xs.isCons() && ys.isCons() && zs.isCons() {
+		v, w, x, y, z := vs.(consList[A]), ws.(consList[B]), xs.(consList[C]), ys.(consList[D]), zs.(consList[E])
+		acc = Cons(fn(v.head, w.head, x.head, y.head, z.head), acc)
+		vs, ws, xs, ys, zs = v.tail, w.tail, x.tail, y.tail, z.tail
 	}
-	return FromSlice(results)
+	return Reverse[F](acc)
+}
+
+func IndexedMap2[A, B, C any](fn func(int, A, B) C, xs List[A], ys List[B]) List[C] {
+	acc := Nil[C]()
+	for i := 0; xs.isCons() && ys.isCons(); i++ {
+		x, y := xs.(consList[A]), ys.(consList[B])
+		acc = Cons(fn(i, x.head, y.head), acc)
+		xs, ys = x.tail, y.tail
+	}
+	return Reverse[C](acc)
+}
+
+func Zip[A, B any](xs List[A], ys List[B]) List[tuple.Tuple[A, B]] {
+	return Map2(tuple.Pair[A, B], xs, ys)
+}
+
+func Zip3[A, B, C any](xs List[A], ys List[B], zs List[C]) List[tuple.Triple[A, B, C]] {
+	return Map3(tuple.Trio[A, B, C], xs, ys, zs)
+}
+
+// GROUP
+
+// Split a list into runs of consecutive elements with the same key. Only
+// neighbours are grouped, so equal keys that are apart end up in separate
+// groups; use `dict.GroupBy` to collect all elements with the same key.
+func GroupBy[T any, K comparable](key func(T) K, ls List[T]) List[List[T]] {
+	return GroupWhile(func(x T, y T) bool {
+		return key(x) == key(y)
+	}, ls)
+}
+
+// Split a list into runs of consecutive elements, starting a new group
+// whenever an element is not in the same group as the one before it. Only
+// neighbours are compared; use `dict.GroupBy` to group all matching elements.
+func GroupWhile[T any](sameGroup func(T, T) bool, ls List[T]) List[List[T]] {
+	groups := Nil[List[T]]()
+	for ls.isCons() {
+		first := ls.(consList[T])
+		group := Singleton(first.head)
+		prev := first.head
+		ls = first.tail
+		for ls.isCons() && sameGroup(prev, ls.(consList[T]).head) {
+			prev = ls.(consList[T]).head
+			group = Cons(prev, group)
+			ls = ls.(consList[T]).tail
+		}
+		groups = Cons(Reverse[T](group), groups)
+	}
+	return Reverse[List[T]](groups)
+}
+
+func Chunk[T any](n int, ls List[T]) List[List[T]] {
+	if n <= 0 {
+		return Nil[List[T]]()
+	}
+	chunks := Nil[List[T]]()
+	for ls.isCons() {
+		split := SplitAt[T](n, ls)
+		chunks = Cons(tuple.First(split), chunks)
+		ls = tuple.Second(split)
+	}
+	return Reverse[List[T]](chunks)
+}
+
+func Windows[T any](n int, ls List[T]) List[List[T]] {
+	if n <= 0 {
+		return Nil[List[T]]()
+	}
+	windows := Nil[List[T]]()
+	for count := Length[T](ls) - n; count >= 0; count-- {
+		windows = Cons(Take[T](n, ls), windows)
+		ls = ls.(consList[T]).tail
+	}
+	return Reverse[List[T]](windows)
+}
+
+// SETS
+
+// The set operations treat lists as sets: results hold each element once, at
+// the position of its first occurrence. The comparable versions are backed by
+// a Go map; the With versions compare every pair and take quadratic time.
+
+func Unique[T comparable](ls List[T]) List[T] {
+	return UniqueBy(nub.Id[T], ls)
+}
+
+func UniqueBy[T any, K comparable](key func(T) K, ls List[T]) List[T] {
+	seen := map[K]struct{}{}
+	kept := Nil[T]()
+	for x := range Values[T](ls) {
+		k := key(x)
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			kept = Cons(x, kept)
+		}
+	}
+	return Reverse[T](kept)
+}
+
+func UniqueWith[T any](eq func(T, T) bool, ls List[T]) List[T] {
+	kept := Nil[T]()
+	for x := range Values[T](ls) {
+		if !memberWith(eq, x, kept) {
+			kept = Cons(x, kept)
+		}
+	}
+	return Reverse[T](kept)
+}
+
+func Union[T comparable](xs List[T], ys List[T]) List[T] {
+	return Unique[T](Append[T](xs, ys))
+}
+
+func UnionWith[T any](eq func(T, T) bool, xs List[T], ys List[T]) List[T] {
+	return UniqueWith(eq, Append[T](xs, ys))
+}
+
+func Intersect[T comparable](xs List[T], ys List[T]) List[T] {
+	others := toSet[T](ys)
+	return Unique[T](Filter(func(x T) bool {
+		_, ok := others[x]
+		return ok
+	}, xs))
+}
+
+func IntersectWith[T any](eq func(T, T) bool, xs List[T], ys List[T]) List[T] {
+	return UniqueWith(eq, Filter(func(x T) bool {
+		return memberWith(eq, x, ys)
+	}, xs))
+}
+
+func Difference[T comparable](xs List[T], ys List[T]) List[T] {
+	others := toSet[T](ys)
+	return Unique[T](Filter(func(x T) bool {
+		_, ok := others[x]
+		return !ok
+	}, xs))
+}
+
+func DifferenceWith[T any](eq func(T, T) bool, xs List[T], ys List[T]) List[T] {
+	return UniqueWith(eq, Filter(func(x T) bool {
+		return !memberWith(eq, x, ys)
+	}, xs))
+}
+
+func IsSubsetOf[T comparable](xs List[T], ys List[T]) bool {
+	others := toSet[T](ys)
+	return All(func(x T) bool {
+		_, ok := others[x]
+		return ok
+	}, xs)
+}
+
+func IsSubsetOfWith[T any](eq func(T, T) bool, xs List[T], ys List[T]) bool {
+	return All(func(x T) bool {
+		return memberWith(eq, x, ys)
+	}, xs)
+}
+
+func memberWith[T any](eq func(T, T) bool, x T, ls List[T]) bool {
+	return Any(func(y T) bool {
+		return eq(x, y)
+	}, ls)
+}
+
+func toSet[T comparable](ls List[T]) map[T]struct{} {
+	set := map[T]struct{}{}
+	for x := range Values[T](ls) {
+		set[x] = struct{}{}
+	}
+	return set
 }
 
 // SORT
 
+// Sort values from lowest to highest. The sort is stable: equal elements keep
+// their original relative order.
 func Sort[T nub.Ord](ls List[T]) List[T] {
 	return SortBy(nub.Id[T], ls)
 }
 
+// Sort values by a derived property. The sort is stable: elements with equal
+// properties keep their original relative order.
 func SortBy[A any, B nub.Ord](fn func(A) B, ls List[A]) List[A] {
-	slice := ToSlice[A](ls)
-
 	sortFn := func(x A, y A) nub.Order {
 		return nub.Compare(fn(x), fn(y))
 	}
 
-	quicksort(sortFn, slice)
-
-	return FromSlice(slice)
+	return SortWith(sortFn, ls)
 }
 
+// Sort values with a custom comparison function. The sort is stable: elements
+// that compare as EQ keep their original relative order. It runs in
+// O(n log n) time and constant stack space.
 func SortWith[T any](sortFn func(T, T) nub.Order, ls List[T]) List[T] {
 	slice := ToSlice[T](ls)
 
-	quicksort(sortFn, slice)
+	mergesort(sortFn, slice)
 
 	return FromSlice(slice)
 }
 
-func quicksort[T any](sortFn func(T, T) nub.Order, xs []T) {
-	quicksortHelp(sortFn, xs, 0, len(xs))
-}
+// Bottom-up merge sort. Runs of doubling width are merged through a scratch
+// buffer, taking from the left run on ties to keep the sort stable.
+func mergesort[T any](sortFn func(T, T) nub.Order, xs []T) {
+	src := xs
+	dst := make([]T, len(xs))
 
-func quicksortHelp[T any](sortFn func(T, T) nub.Order, xs []T, lo int, hi int) {
-	if lo < hi {
-		pivotIndex := partition(sortFn, xs, lo, hi)
-		quicksortHelp(sortFn, xs, lo, pivotIndex)
-		quicksortHelp(sortFn, xs, pivotIndex+1, hi)
+	for width := 1; width < len(xs); width *= 2 {
+		for lo := 0; lo < len(xs); lo += 2 * width {
+			mid := min(lo+width, len(xs))
+			hi := min(lo+2*width, len(xs))
+			merge(sortFn, src, dst, lo, mid, hi)
+		}
+		src, dst = dst, src
 	}
-}
 
-func partition[T any](sortFn func(T, T) nub.Order, slice []T, lo int, hi int) int {
-	swap := func(i int, j int) {
-		tmp := slice[i]
-		slice[i] = slice[j]
-		slice[j] = tmp
+	if len(xs) > 0 && &src[0] != &xs[0] {
+		copy(xs, src)
 	}
+}
 
-	pivot := slice[lo]
-	x := lo + 1
+func merge[T any](sortFn func(T, T) nub.Order, src []T, dst []T, lo int, mid int, hi int) {
+	i, j := lo, mid
+	for k := lo; k < hi; k++ {
+		if i < mid && (j >= hi || sortFn(src[j], src[i]) != nub.LT) {
+			dst[k] = src[i]
+			i++
+		} else {
+			dst[k] = src[j]
+			j++
+		}
+	}
+}
 
-	for i := x; i < hi; i++ {
-		if sortFn(slice[i], pivot) == nub.LT {
-			swap(i, x)
-			x++
+// Compare lists element by element. A list that is a prefix of another comes
+// first.
+func Lexicographic[T any](c nub.Comparator[T]) nub.Comparator[List[T]] {
+	return func(xs List[T], ys List[T]) nub.Order {
+		for xs.isCons() && ys.isCons() {
+			x, y := xs.(consList[T]), ys.(consList[T])
+			if o := c(x.head, y.head); o != nub.EQ {
+				return o
+			}
+			xs, ys = x.tail, y.tail
 		}
+		switch {
+		case xs.isCons():
+			return nub.GT
+		case ys.isCons():
+			return nub.LT
+		}
+		return nub.EQ
 	}
-	swap(lo, x-1)
-	return x - 1
 }
 
 // DECONSTRUCT
 
 func IsEmpty[T any](list List[T]) bool {
-	return Length[T](list) == 0
+	return !list.isCons()
 }
 
 func Head[T any](list List[T]) maybe.Maybe[T] {
@@ -364,35 +751,138 @@ func Tail[T any](list List[T]) List[T] {
 }
 
 func Take[T any](n int, ls List[T]) List[T] {
-	if n <= 0 {
-		return Nil[T]()
-	} else {
-		switch ls.(type) {
-		case nilList[T]:
-			return ls
-		}
+	return tuple.First(SplitAt[T](n, ls))
+}
+
+func Drop[T any](n int, ls List[T]) List[T] {
+	for ; n > 0 && ls.isCons(); n-- {
+		ls = ls.(consList[T]).tail
+	}
+	return ls
+}
+
+func SplitAt[T any](n int, ls List[T]) tuple.Tuple[List[T], List[T]] {
+	prefix := Nil[T]()
+	for ; n > 0 && ls.isCons(); n-- {
+		prefix = Cons(ls.(consList[T]).head, prefix)
+		ls = ls.(consList[T]).tail
+	}
+	return tuple.Pair(Reverse[T](prefix), ls)
+}
+
+func Span[T any](predicate func(T) bool, ls List[T]) tuple.Tuple[List[T], List[T]] {
+	prefix := Nil[T]()
+	for ls.isCons() && predicate(ls.(consList[T]).head) {
+		prefix = Cons(ls.(consList[T]).head, prefix)
+		ls = ls.(consList[T]).tail
+	}
+	return tuple.Pair(Reverse[T](prefix), ls)
+}
+
+func Break[T any](predicate func(T) bool, ls List[T]) tuple.Tuple[List[T], List[T]] {
+	return Span(nub.Compose(nub.Not, predicate), ls)
+}
 
-		slice := ToSlice[T](ls)
+func TakeWhile[T any](predicate func(T) bool, ls List[T]) List[T] {
+	return tuple.First(Span(predicate, ls))
+}
 
-		if n < len(slice) {
-			slice = slice[:n]
+func DropWhile[T any](predicate func(T) bool, ls List[T]) List[T] {
+	for ls.isCons() && predicate(ls.(consList[T]).head) {
+		ls = ls.(consList[T]).tail
+	}
+	return ls
+}
+
+// SEARCH
+
+func Find[T any](predicate func(T) bool, ls List[T]) maybe.Maybe[T] {
+	for x := range Values[T](ls) {
+		if predicate(x) {
+			return maybe.Just(x)
 		}
+	}
+	return maybe.Nothing[T]()
+}
 
-		return FromSlice(slice)
+func FindIndex[T any](predicate func(T) bool, ls List[T]) maybe.Maybe[int] {
+	i := 0
+	for x := range Values[T](ls) {
+		if predicate(x) {
+			return maybe.Just(i)
+		}
+		i++
 	}
+	return maybe.Nothing[int]()
 }
 
-func Drop[T any](n int, ls List[T]) List[T] {
-	if n <= 0 {
+func ElemIndex[T comparable](x T, ls List[T]) maybe.Maybe[int] {
+	return FindIndex(nub.Curry(nub.Eq[T])(x), ls)
+}
+
+func Lookup[K comparable, V any](key K, ls List[tuple.Tuple[K, V]]) maybe.Maybe[V] {
+	return maybe.Map(tuple.Second[K, V], Find(func(pair tuple.Tuple[K, V]) bool {
+		return tuple.First(pair) == key
+	}, ls))
+}
+
+// INDEXED ACCESS
+
+// Indices out of range leave the list unchanged, or return Nothing where a
+// value is expected.
+
+func GetAt[T any](n int, ls List[T]) maybe.Maybe[T] {
+	if n < 0 {
+		return maybe.Nothing[T]()
+	}
+	return Head[T](Drop[T](n, ls))
+}
+
+func SetAt[T any](n int, x T, ls List[T]) List[T] {
+	return UpdateAt(n, nub.Const[T, T](x), ls)
+}
+
+func UpdateAt[T any](n int, fn func(T) T, ls List[T]) List[T] {
+	if n < 0 {
 		return ls
-	} else {
-		switch ls.(type) {
-		case nilList[T]:
-			return ls
-		}
+	}
+	split := SplitAt[T](n, ls)
+	rest := tuple.Second(split)
+	if !rest.isCons() {
+		return ls
+	}
+	return prependReversed[T](Reverse[T](tuple.First(split)), Cons(fn(rest.(consList[T]).head), rest.(consList[T]).tail))
+}
 
-		return Drop[T](n-1, ls.(consList[T]).tail)
+func InsertAt[T any](n int, x T, ls List[T]) List[T] {
+	if n < 0 {
+		return ls
+	}
+	split := SplitAt[T](n, ls)
+	if Length[T](tuple.First(split)) < n {
+		return ls
 	}
+	return prependReversed[T](Reverse[T](tuple.First(split)), Cons(x, tuple.Second(split)))
+}
+
+func RemoveAt[T any](n int, ls List[T]) List[T] {
+	if n < 0 {
+		return ls
+	}
+	split := SplitAt[T](n, ls)
+	rest := tuple.Second(split)
+	if !rest.isCons() {
+		return ls
+	}
+	return prependReversed[T](Reverse[T](tuple.First(split)), rest.(consList[T]).tail)
+}
+
+// Cons the elements of a reversed prefix back onto a list, sharing the tail.
+func prependReversed[T any](reversed List[T], ls List[T]) List[T] {
+	for x := range Values[T](reversed) {
+		ls = Cons(x, ls)
+	}
+	return ls
 }
 
 func Partition[T any](predicate func(T) bool, ls List[T]) tuple.Tuple[List[T], List[T]] {
@@ -415,3 +905,13 @@ func Unzip[A, B any](xs List[tuple.Tuple[A, B]]) tuple.Tuple[List[A], List[B]] {
 	}
 	return FoldR(step, tuple.Pair(Nil[A](), Nil[B]()), xs)
 }
+
+func Unzip3[A, B, C any](xs List[tuple.Triple[A, B, C]]) tuple.Triple[List[A], List[B], List[C]] {
+	as, bs, cs := Nil[A](), Nil[B](), Nil[C]()
+	for x := range Values[tuple.Triple[A, B, C]](Reverse[tuple.Triple[A, B, C]](xs)) {
+		as = Cons(tuple.First3(x), as)
+		bs = Cons(tuple.Second3(x), bs)
+		cs = Cons(tuple.Third3(x), cs)
+	}
+	return tuple.Trio(as, bs, cs)
+}
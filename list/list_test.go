@@ -1,7 +1,11 @@
 package list
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
+	rdebug "runtime/debug"
+	"slices"
 	"testing"
 
 	"github.com/obiloud/curry-go/debug"
@@ -449,3 +453,626 @@ func testListOfN(n int, t *testing.T) {
 		t.Errorf("sortWith %d elements unsorted", n)
 	}
 }
+
+func TestStackSafety(t *testing.T) {
+	// Every traversal must run in constant stack space, so cap the goroutine
+	// stack well below what a recursion over a million elements would need.
+	defer rdebug.SetMaxStack(rdebug.SetMaxStack(1 << 20))
+
+	n := 1000000
+	xs := Range(1, n)
+
+	if Length[int](xs) != n {
+		t.Error("Length")
+	}
+
+	if Length[int](Repeat(n, 0)) != n {
+		t.Error("Repeat")
+	}
+
+	if Any(func(x int) bool { return x > n }, xs) {
+		t.Error("Any")
+	}
+
+	if !All(func(x int) bool { return x > 0 }, xs) {
+		t.Error("All")
+	}
+
+	if !Member(n, xs) {
+		t.Error("Member")
+	}
+
+	if FoldL(func(x int, acc int) int { return acc + x }, 0, xs) != n*(n+1)/2 {
+		t.Error("FoldL")
+	}
+
+	if FoldR(func(x int, acc int) int { return x }, 0, xs) != 1 {
+		t.Error("FoldR")
+	}
+
+	if Head[int](Reverse[int](xs)) != maybe.Just(n) {
+		t.Error("Reverse")
+	}
+
+	if Head[int](Drop[int](n-1, xs)) != maybe.Just(n) {
+		t.Error("Drop")
+	}
+
+	if Length[int](Take[int](n-1, xs)) != n-1 {
+		t.Error("Take")
+	}
+
+	if Sum[int](Map(func(x int) int { return 1 }, xs)) != n {
+		t.Error("Map")
+	}
+
+	if Length[int](Filter(func(x int) bool { return x%2 == 0 }, xs)) != n/2 {
+		t.Error("Filter")
+	}
+
+	if Length[int](FilterMap(maybe.Just[int], xs)) != n {
+		t.Error("FilterMap")
+	}
+
+	if Length[int](Append[int](xs, xs)) != 2*n {
+		t.Error("Append")
+	}
+
+	if Length[int](Concat[int](Repeat(n, Singleton(0)))) != n {
+		t.Error("Concat")
+	}
+
+	if Length[int](ConcatMap(Singleton[int], xs)) != n {
+		t.Error("ConcatMap")
+	}
+
+	if Length[int](Intersperse(0, xs)) != 2*n-1 {
+		t.Error("Intersperse")
+	}
+
+	if Length[int](IndexedMap(func(i int, x int) int { return i + x }, xs)) != n {
+		t.Error("IndexedMap")
+	}
+
+	if len(ToSlice[int](FromSlice(ToSlice[int](xs)))) != n {
+		t.Error("ToSlice / FromSlice")
+	}
+
+	if Maximum[int](xs) != maybe.Just(n) || Minimum[int](xs) != maybe.Just(1) {
+		t.Error("Maximum / Minimum")
+	}
+
+	if Length[int](tuple.First(Partition(func(x int) bool { return x > 0 }, xs))) != n {
+		t.Error("Partition")
+	}
+
+	if Length[int](tuple.Second(Unzip[int, int](Map(func(x int) tuple.Tuple[int, int] { return tuple.Pair(x, x) }, xs)))) != n {
+		t.Error("Unzip")
+	}
+}
+
+func TestValues(t *testing.T) {
+	xs := Range(1, 5)
+
+	if !slices.Equal(slices.Collect(Values[int](xs)), []int{1, 2, 3, 4, 5}) {
+		t.Error("Values in order")
+	}
+
+	if len(slices.Collect(Values[int](Nil[int]()))) != 0 {
+		t.Error("Values of Nil")
+	}
+
+	sum := 0
+	for x := range Values[int](xs) {
+		if x > 3 {
+			break
+		}
+		sum += x
+	}
+	if sum != 6 {
+		t.Error("Values break")
+	}
+
+	if FromSeq(Values[int](xs)) != xs {
+		t.Error("FromSeq round trip")
+	}
+
+	if FromSeq(slices.Values([]int{})) != Nil[int]() {
+		t.Error("FromSeq empty")
+	}
+}
+
+func TestSortLarge(t *testing.T) {
+	defer rdebug.SetMaxStack(rdebug.SetMaxStack(1 << 20))
+
+	n := 300000
+	sorted := Range(1, n)
+
+	// Compare as slices: == on long lists recurses through every cons cell.
+	equal := func(xs List[int], ys List[int]) bool {
+		return slices.Equal(ToSlice[int](xs), ToSlice[int](ys))
+	}
+
+	if !equal(Sort[int](sorted), sorted) {
+		t.Error("sort already sorted input")
+	}
+
+	if !equal(Sort[int](Reverse[int](sorted)), sorted) {
+		t.Error("sort reverse sorted input")
+	}
+
+	duplicates := Map(func(x int) int { return x % 3 }, sorted)
+	expected := Concat[int](FromSlice([]List[int]{Repeat(n/3, 0), Repeat(n/3, 1), Repeat(n/3, 2)}))
+
+	if !equal(Sort[int](duplicates), expected) {
+		t.Error("sort duplicate heavy input")
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	records := FromSlice([]tuple.Tuple[string, int]{
+		tuple.Pair("b", 1),
+		tuple.Pair("a", 2),
+		tuple.Pair("b", 3),
+		tuple.Pair("a", 4),
+		tuple.Pair("c", 5),
+		tuple.Pair("a", 6),
+	})
+
+	expected := FromSlice([]tuple.Tuple[string, int]{
+		tuple.Pair("a", 2),
+		tuple.Pair("a", 4),
+		tuple.Pair("a", 6),
+		tuple.Pair("b", 1),
+		tuple.Pair("b", 3),
+		tuple.Pair("c", 5),
+	})
+
+	if SortBy(tuple.First[string, int], records) != expected {
+		t.Error("sortBy keeps equal keys in their original order")
+	}
+
+	byKey := func(x tuple.Tuple[string, int], y tuple.Tuple[string, int]) nub.Order {
+		return nub.Compare(tuple.First(x), tuple.First(y))
+	}
+
+	if SortWith(byKey, records) != expected {
+		t.Error("sortWith keeps equal keys in their original order")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	cases := []tuple.Tuple[List[int], string]{
+		tuple.Pair(Nil[int](), "[]"),
+		tuple.Pair(Singleton(1), "[1]"),
+		tuple.Pair(Range(1, 3), "[1,2,3]"),
+	}
+
+	for _, c := range cases {
+		data, err := json.Marshal(tuple.First(c))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tuple.Second(c) {
+			t.Errorf("List encodes as an array, got %s", data)
+		}
+		decoded, err := FromJSON[int](data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decoded != tuple.First(c) {
+			t.Errorf("List round trip %s", data)
+		}
+	}
+
+	if _, err := FromJSON[int]([]byte(`{"a": 1}`)); err == nil {
+		t.Error("List from an object")
+	}
+
+	var plain struct {
+		Items List[int] `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(`{"items":[1,2]}`), &plain); err == nil {
+		t.Error("a List field cannot be decoded")
+	}
+
+	type order struct {
+		Items JSON[int] `json:"items"`
+		Notes JSON[int] `json:"notes"`
+	}
+	data, err := json.Marshal(order{Items: AsJSON[int](Range(1, 2))})
+	if err != nil || string(data) != `{"items":[1,2],"notes":[]}` {
+		t.Errorf("JSON fields encode as lists, got %s", data)
+	}
+
+	var decoded order
+	if err := json.Unmarshal([]byte(`{"items":[1,2],"notes":null}`), &decoded); err != nil || decoded.Items.List() != Range(1, 2) || decoded.Notes.List() != Nil[int]() {
+		t.Error("JSON fields round trip")
+	}
+}
+
+func TestTraverseMaybe(t *testing.T) {
+	halve := func(x int) maybe.Maybe[int] {
+		if x%2 == 0 {
+			return maybe.Just(x / 2)
+		}
+		return maybe.Nothing[int]()
+	}
+
+	if TraverseMaybe(halve, FromSlice([]int{2, 4, 6})) != maybe.Just(Range(1, 3)) {
+		t.Error("traverse all Just")
+	}
+
+	calls := 0
+	counted := func(x int) maybe.Maybe[int] {
+		calls++
+		return halve(x)
+	}
+
+	if TraverseMaybe(counted, FromSlice([]int{2, 3, 4})) != maybe.Nothing[List[int]]() || calls != 2 {
+		t.Error("traverse stops at the first Nothing")
+	}
+
+	if TraverseMaybe(halve, Nil[int]()) != maybe.Just(Nil[int]()) {
+		t.Error("traverse Nil")
+	}
+
+	if SequenceMaybe[int](FromSlice([]maybe.Maybe[int]{maybe.Just(1), maybe.Just(2)})) != maybe.Just(Range(1, 2)) {
+		t.Error("sequence all Just")
+	}
+
+	if SequenceMaybe[int](FromSlice([]maybe.Maybe[int]{maybe.Just(1), maybe.Nothing[int]()})) != maybe.Nothing[List[int]]() {
+		t.Error("sequence with Nothing")
+	}
+}
+
+func TestZip(t *testing.T) {
+	xs := Range(1, 3)
+	ys := FromSlice([]string{"a", "b"})
+	zs := FromSlice([]bool{true, false, true})
+
+	if Zip[int, string](xs, ys) != FromSlice([]tuple.Tuple[int, string]{tuple.Pair(1, "a"), tuple.Pair(2, "b")}) {
+		t.Error("zip stops at the shorter list")
+	}
+
+	if Zip3[int, string, bool](xs, ys, zs) != FromSlice([]tuple.Triple[int, string, bool]{tuple.Trio(1, "a", true), tuple.Trio(2, "b", false)}) {
+		t.Error("zip3 stops at the shortest list")
+	}
+
+	if Unzip3[int, int, int](Zip3[int, int, int](xs, xs, xs)) != tuple.Trio(xs, xs, xs) {
+		t.Error("unzip3")
+	}
+
+	if Unzip3[int, int, int](Nil[tuple.Triple[int, int, int]]()) != tuple.Trio(Nil[int](), Nil[int](), Nil[int]()) {
+		t.Error("unzip3 Nil")
+	}
+
+	sum3 := func(a, b, c int) int { return a + b + c }
+	sum4 := func(a, b, c, d int) int { return a + b + c + d }
+	sum5 := func(a, b, c, d, e int) int { return a + b + c + d + e }
+
+	if Map3(sum3, xs, xs, Range(1, 2)) != FromSlice([]int{3, 6}) {
+		t.Error("map3")
+	}
+
+	if Map4(sum4, xs, xs, xs, xs) != FromSlice([]int{4, 8, 12}) {
+		t.Error("map4")
+	}
+
+	if Map5(sum5, xs, xs, xs, xs, Nil[int]()) != Nil[int]() {
+		t.Error("map5 with Nil")
+	}
+
+	if Map5(sum5, xs, xs, xs, xs, xs) != FromSlice([]int{5, 10, 15}) {
+		t.Error("map5")
+	}
+
+	indexed := func(i int, x int, s string) string {
+		return fmt.Sprintf("%d:%d%s", i, x, s)
+	}
+
+	if IndexedMap2(indexed, xs, ys) != FromSlice([]string{"0:1a", "1:2b"}) {
+		t.Error("indexedMap2")
+	}
+}
+
+func TestGroup(t *testing.T) {
+	events := FromSlice([]string{"a1", "a2", "b1", "a3", "c1", "c2"})
+	initial := func(s string) byte {
+		return s[0]
+	}
+
+	if GroupBy(initial, events) != FromSlice([]List[string]{
+		FromSlice([]string{"a1", "a2"}),
+		Singleton("b1"),
+		Singleton("a3"),
+		FromSlice([]string{"c1", "c2"}),
+	}) {
+		t.Error("groupBy groups consecutive equal keys")
+	}
+
+	if GroupBy(initial, Nil[string]()) != Nil[List[string]]() {
+		t.Error("groupBy Nil")
+	}
+
+	ascending := func(x int, y int) bool {
+		return y == x+1
+	}
+
+	if GroupWhile(ascending, FromSlice([]int{1, 2, 3, 5, 6, 8})) != FromSlice([]List[int]{Range(1, 3), Range(5, 6), Singleton(8)}) {
+		t.Error("groupWhile compares neighbours")
+	}
+
+	if Chunk[int](2, Range(1, 5)) != FromSlice([]List[int]{Range(1, 2), Range(3, 4), Singleton(5)}) {
+		t.Error("chunk with a short last chunk")
+	}
+
+	if Chunk[int](5, Range(1, 5)) != Singleton(Range(1, 5)) {
+		t.Error("chunk of the whole list")
+	}
+
+	if Chunk[int](0, Range(1, 5)) != Nil[List[int]]() || Chunk[int](2, Nil[int]()) != Nil[List[int]]() {
+		t.Error("chunk of size 0 or of Nil")
+	}
+
+	if Windows[int](3, Range(1, 5)) != FromSlice([]List[int]{Range(1, 3), Range(2, 4), Range(3, 5)}) {
+		t.Error("windows")
+	}
+
+	if Windows[int](6, Range(1, 5)) != Nil[List[int]]() || Windows[int](0, Range(1, 5)) != Nil[List[int]]() {
+		t.Error("windows larger than the list or of size 0")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	xs := Range(1, 5)
+	small := func(x int) bool {
+		return x < 3
+	}
+
+	if SplitAt[int](2, xs) != tuple.Pair(Range(1, 2), Range(3, 5)) {
+		t.Error("splitAt")
+	}
+
+	if SplitAt[int](-1, xs) != tuple.Pair(Nil[int](), xs) || SplitAt[int](9, xs) != tuple.Pair(xs, Nil[int]()) {
+		t.Error("splitAt out of range")
+	}
+
+	if Span(small, xs) != tuple.Pair(Range(1, 2), Range(3, 5)) {
+		t.Error("span")
+	}
+
+	if Break(small, xs) != tuple.Pair(Nil[int](), xs) {
+		t.Error("break")
+	}
+
+	if TakeWhile(small, xs) != Range(1, 2) || TakeWhile(small, Nil[int]()) != Nil[int]() {
+		t.Error("takeWhile")
+	}
+
+	if DropWhile(small, xs) != Range(3, 5) || DropWhile(nub.Const[bool, int](true), xs) != Nil[int]() {
+		t.Error("dropWhile")
+	}
+}
+
+func TestScan(t *testing.T) {
+	sum := func(x int, acc int) int {
+		return x + acc
+	}
+
+	if ScanL(sum, 0, Range(1, 3)) != FromSlice([]int{0, 1, 3, 6}) {
+		t.Error("scanL running totals")
+	}
+
+	if ScanR(sum, 0, Range(1, 3)) != FromSlice([]int{6, 5, 3, 0}) {
+		t.Error("scanR running totals")
+	}
+
+	if ScanL(sum, 0, Nil[int]()) != Singleton(0) || ScanR(sum, 0, Nil[int]()) != Singleton(0) {
+		t.Error("scan Nil")
+	}
+
+	number := func(s string, n int) tuple.Tuple[int, string] {
+		return tuple.Pair(n+1, fmt.Sprintf("%d.%s", n, s))
+	}
+
+	abc := FromSlice([]string{"a", "b", "c"})
+
+	if MapAccumL(number, 1, abc) != tuple.Pair(4, FromSlice([]string{"1.a", "2.b", "3.c"})) {
+		t.Error("mapAccumL numbers from the left")
+	}
+
+	if MapAccumR(number, 1, abc) != tuple.Pair(4, FromSlice([]string{"3.a", "2.b", "1.c"})) {
+		t.Error("mapAccumR numbers from the right")
+	}
+}
+
+func TestUnfold(t *testing.T) {
+	countdown := func(n int) maybe.Maybe[tuple.Tuple[int, int]] {
+		if n == 0 {
+			return maybe.Nothing[tuple.Tuple[int, int]]()
+		}
+		return maybe.Just(tuple.Pair(n, n-1))
+	}
+
+	if Unfoldr(countdown, 3) != FromSlice([]int{3, 2, 1}) || Unfoldr(countdown, 0) != Nil[int]() {
+		t.Error("unfoldr")
+	}
+
+	double := func(x int) int {
+		return x * 2
+	}
+
+	if Iterate(4, double, 1) != FromSlice([]int{1, 2, 4, 8}) || Iterate(0, double, 1) != Nil[int]() {
+		t.Error("iterate")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	xs := FromSlice([]int{5, 6, 7, 6})
+	even := func(x int) bool {
+		return x%2 == 0
+	}
+
+	if Find(even, xs) != maybe.Just(6) || Find(even, Singleton(1)) != maybe.Nothing[int]() {
+		t.Error("find")
+	}
+
+	if FindIndex(even, xs) != maybe.Just(1) || FindIndex(even, Nil[int]()) != maybe.Nothing[int]() {
+		t.Error("findIndex")
+	}
+
+	if ElemIndex(7, xs) != maybe.Just(2) || ElemIndex(8, xs) != maybe.Nothing[int]() {
+		t.Error("elemIndex")
+	}
+
+	pairs := FromSlice([]tuple.Tuple[string, int]{tuple.Pair("a", 1), tuple.Pair("b", 2), tuple.Pair("a", 3)})
+
+	if Lookup[string, int]("a", pairs) != maybe.Just(1) || Lookup[string, int]("c", pairs) != maybe.Nothing[int]() {
+		t.Error("lookup finds the first matching key")
+	}
+}
+
+func TestIndexedAccess(t *testing.T) {
+	xs := Range(0, 4)
+
+	if GetAt[int](2, xs) != maybe.Just(2) || GetAt[int](5, xs) != maybe.Nothing[int]() || GetAt[int](-1, xs) != maybe.Nothing[int]() {
+		t.Error("getAt")
+	}
+
+	if SetAt(2, 9, xs) != FromSlice([]int{0, 1, 9, 3, 4}) || SetAt(5, 9, xs) != xs || SetAt(-1, 9, xs) != xs {
+		t.Error("setAt")
+	}
+
+	if UpdateAt(0, nub.Negate[int], Range(1, 3)) != FromSlice([]int{-1, 2, 3}) || UpdateAt(3, nub.Negate[int], Range(1, 3)) != Range(1, 3) {
+		t.Error("updateAt")
+	}
+
+	if InsertAt(2, 9, xs) != FromSlice([]int{0, 1, 9, 2, 3, 4}) {
+		t.Error("insertAt middle")
+	}
+
+	if InsertAt(5, 9, xs) != FromSlice([]int{0, 1, 2, 3, 4, 9}) || InsertAt(0, 9, Nil[int]()) != Singleton(9) {
+		t.Error("insertAt end")
+	}
+
+	if InsertAt(6, 9, xs) != xs || InsertAt(-1, 9, xs) != xs {
+		t.Error("insertAt out of range")
+	}
+
+	if RemoveAt[int](4, xs) != Range(0, 3) || RemoveAt[int](0, xs) != Range(1, 4) || RemoveAt[int](5, xs) != xs {
+		t.Error("removeAt")
+	}
+
+	rest := Range(3, 4)
+	shared := SetAt(0, 9, Cons(0, rest))
+	if Tail[int](shared) != rest {
+		t.Error("setAt shares the tail")
+	}
+}
+
+func TestSets(t *testing.T) {
+	xs := FromSlice([]int{3, 1, 3, 2, 1})
+	ys := FromSlice([]int{2, 4, 3, 4})
+	sameParity := func(x int, y int) bool {
+		return x%2 == y%2
+	}
+
+	if Unique[int](xs) != FromSlice([]int{3, 1, 2}) || UniqueWith(nub.Eq[int], xs) != FromSlice([]int{3, 1, 2}) {
+		t.Error("unique keeps first occurrences in order")
+	}
+
+	if UniqueBy(func(s string) int { return len(s) }, FromSlice([]string{"a", "bb", "c", "dd", "eee"})) != FromSlice([]string{"a", "bb", "eee"}) {
+		t.Error("uniqueBy")
+	}
+
+	if UniqueWith(sameParity, xs) != FromSlice([]int{3, 2}) {
+		t.Error("uniqueWith")
+	}
+
+	if Union[int](xs, ys) != FromSlice([]int{3, 1, 2, 4}) || UnionWith(nub.Eq[int], xs, ys) != FromSlice([]int{3, 1, 2, 4}) {
+		t.Error("union")
+	}
+
+	if Intersect[int](xs, ys) != FromSlice([]int{3, 2}) || IntersectWith(nub.Eq[int], xs, ys) != FromSlice([]int{3, 2}) {
+		t.Error("intersect")
+	}
+
+	if Difference[int](xs, ys) != Singleton(1) || DifferenceWith(nub.Eq[int], xs, ys) != Singleton(1) {
+		t.Error("difference")
+	}
+
+	if DifferenceWith(sameParity, xs, Singleton(2)) != Singleton(3) {
+		t.Error("differenceWith")
+	}
+
+	if !IsSubsetOf[int](FromSlice([]int{2, 3}), xs) || IsSubsetOf[int](ys, xs) || !IsSubsetOf[int](Nil[int](), ys) {
+		t.Error("isSubsetOf")
+	}
+
+	if !IsSubsetOfWith(sameParity, ys, Range(1, 2)) || IsSubsetOfWith(sameParity, ys, Singleton(2)) {
+		t.Error("isSubsetOfWith")
+	}
+
+	n := 100000
+	if Length[int](Unique[int](Append[int](Range(1, n), Range(1, n)))) != n {
+		t.Error("unique on a large list")
+	}
+}
+
+func TestExtremes(t *testing.T) {
+	names := FromSlice([]string{"bob", "alice", "eve", "carol"})
+
+	if Maximum[string](names) != maybe.Just("eve") || Minimum[string](names) != maybe.Just("alice") {
+		t.Error("maximum / minimum of strings")
+	}
+
+	length := func(s string) int {
+		return len(s)
+	}
+
+	if MaximumBy(length, names) != maybe.Just("alice") {
+		t.Error("maximumBy returns the first of the longest")
+	}
+
+	if MinimumBy(length, names) != maybe.Just("bob") {
+		t.Error("minimumBy returns the first of the shortest")
+	}
+
+	if MaximumBy(length, Nil[string]()) != maybe.Nothing[string]() || MinimumBy(length, Nil[string]()) != maybe.Nothing[string]() {
+		t.Error("maximumBy / minimumBy of Nil")
+	}
+
+	byLastLetter := func(x string, y string) nub.Order {
+		return nub.Compare(x[len(x)-1:], y[len(y)-1:])
+	}
+
+	if MaximumWith(byLastLetter, names) != maybe.Just("carol") || MinimumWith(byLastLetter, names) != maybe.Just("bob") {
+		t.Error("maximumWith / minimumWith")
+	}
+
+	if MaximumWith(byLastLetter, Nil[string]()) != maybe.Nothing[string]() {
+		t.Error("maximumWith of Nil")
+	}
+}
+
+func TestLexicographic(t *testing.T) {
+	lex := Lexicographic(nub.Natural[int]())
+
+	if lex(FromSlice([]int{1, 2, 3}), FromSlice([]int{1, 2, 4})) != nub.LT {
+		t.Error("first differing element decides")
+	}
+
+	if lex(FromSlice([]int{1, 2}), FromSlice([]int{1, 2, 0})) != nub.LT || lex(FromSlice([]int{1, 2, 0}), FromSlice([]int{1, 2})) != nub.GT {
+		t.Error("a prefix sorts first")
+	}
+
+	if lex(Nil[int](), Nil[int]()) != nub.EQ || lex(Range(1, 3), Range(1, 3)) != nub.EQ {
+		t.Error("equal lists")
+	}
+
+	sorted := SortWith(lex, FromSlice([]List[int]{Range(2, 3), Range(1, 3), Range(1, 2)}))
+	if fmt.Sprint(sorted) != fmt.Sprint(FromSlice([]List[int]{Range(1, 2), Range(1, 3), Range(2, 3)})) {
+		t.Error("SortWith accepts a Comparator")
+	}
+}
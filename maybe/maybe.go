@@ -1,8 +1,11 @@
 package maybe
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 
 	"github.com/obiloud/curry-go/nub"
 	"github.com/obiloud/curry-go/util"
@@ -52,6 +55,65 @@ func (n nothing[T]) String() string {
 	return "Nothing"
 }
 
+func (j just[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.obj)
+}
+
+func (n nothing[T]) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+// Decode a JSON value into a Maybe. `null` decodes to Nothing, so a Just
+// holding a value that encodes as `null` does not round-trip.
+//
+// Maybe is an interface, so `json.Unmarshal` cannot decode into it when it is
+// a struct field, a map or dict value, or a tuple element. Use JSON in those
+// places instead.
+func FromJSON[T any](data []byte) (Maybe[T], error) {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return Nothing[T](), nil
+	}
+	var x T
+	if err := json.Unmarshal(data, &x); err != nil {
+		return Nothing[T](), err
+	}
+	return Just(x), nil
+}
+
+// A Maybe that `json.Unmarshal` can decode, for struct fields and other values
+// nested inside JSON. It encodes the same way as the Maybe it holds, and its
+// zero value, like a missing field, is Nothing.
+type JSON[T any] struct {
+	maybe Maybe[T]
+}
+
+// Wrap a Maybe so it can be encoded and decoded as part of a larger value.
+func AsJSON[T any](m Maybe[T]) JSON[T] {
+	return JSON[T]{maybe: m}
+}
+
+// The wrapped Maybe.
+func (j JSON[T]) Maybe() Maybe[T] {
+	if j.maybe == nil {
+		return Nothing[T]()
+	}
+	return j.maybe
+}
+
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Maybe())
+}
+
+// Unlike other decoders, `null` is not ignored: it decodes to Nothing.
+func (j *JSON[T]) UnmarshalJSON(data []byte) error {
+	m, err := FromJSON[T](data)
+	if err != nil {
+		return err
+	}
+	j.maybe = m
+	return nil
+}
+
 func Just[T any](x T) Maybe[T] {
 	return just[T]{obj: x}
 }
@@ -85,6 +147,27 @@ func Map2[A, B, C any](fn func(A, B) C, m1 Maybe[A], m2 Maybe[B]) Maybe[C] {
 	return Nothing[C]()
 }
 
+func Map3[A, B, C, D any](fn func(A, B, C) D, m1 Maybe[A], m2 Maybe[B], m3 Maybe[C]) Maybe[D] {
+	if m1.IsJust() && m2.IsJust() && m3.IsJust() {
+		return Just(fn(m1.(just[A]).obj, m2.(just[B]).obj, m3.(just[C]).obj))
+	}
+	return Nothing[D]()
+}
+
+func Map4[A, B, C, D, E any](fn func(A, B, C, D) E, m1 Maybe[A], m2 Maybe[B], m3 Maybe[C], m4 Maybe[D]) Maybe[E] {
+	if m1.IsJust() && m2.IsJust() && m3.IsJust() && m4.IsJust() {
+		return Just(fn(m1.(just[A]).obj, m2.(just[B]).obj, m3.(just[C]).obj, m4.(just[D]).obj))
+	}
+	return Nothing[E]()
+}
+
+func Map5[A, B, C, D, E, F any](fn func(A, B, C, D, E) F, m1 Maybe[A], m2 Maybe[B], m3 Maybe[C], m4 Maybe[D], m5 Maybe[E]) Maybe[F] {
+	if m1.IsJust() && m2.IsJust() && m3.IsJust() && m4.IsJust() && m5.IsJust() {
+		return Just(fn(m1.(just[A]).obj, m2.(just[B]).obj, m3.(just[C]).obj, m4.(just[D]).obj, m5.(just[E]).obj))
+	}
+	return Nothing[F]()
+}
+
 func Apply[A, B any](ma Maybe[func(A) B], mb Maybe[A]) Maybe[B] {
 	return Map2(nub.ApplyFlipped[A, B], mb, ma)
 }
@@ -95,3 +178,57 @@ func Bind[A, B any](fn func(A) Maybe[B], m Maybe[A]) Maybe[B] {
 	}
 	return Nothing[B]()
 }
+
+func All[T any](m Maybe[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if j, ok := m.(just[T]); ok {
+			yield(j.obj)
+		}
+	}
+}
+
+func Match[A, B any](m Maybe[A], onJust func(A) B, onNothing func() B) B {
+	if j, ok := m.(just[A]); ok {
+		return onJust(j.obj)
+	}
+	return onNothing()
+}
+
+func Get[T any](m Maybe[T]) (T, bool) {
+	if j, ok := m.(just[T]); ok {
+		return j.obj, true
+	}
+	return *new(T), false
+}
+
+func NilsFirst[T any](c nub.Comparator[T]) nub.Comparator[Maybe[T]] {
+	return func(x Maybe[T], y Maybe[T]) nub.Order {
+		a, okA := Get[T](x)
+		b, okB := Get[T](y)
+		switch {
+		case okA && okB:
+			return c(a, b)
+		case okA:
+			return nub.GT
+		case okB:
+			return nub.LT
+		}
+		return nub.EQ
+	}
+}
+
+func NilsLast[T any](c nub.Comparator[T]) nub.Comparator[Maybe[T]] {
+	return func(x Maybe[T], y Maybe[T]) nub.Order {
+		a, okA := Get[T](x)
+		b, okB := Get[T](y)
+		switch {
+		case okA && okB:
+			return c(a, b)
+		case okA:
+			return nub.LT
+		case okB:
+			return nub.GT
+		}
+		return nub.EQ
+	}
+}
@@ -1,6 +1,12 @@
 package maybe
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/tuple"
+)
 
 func TestWithDefault(t *testing.T) {
 	if WithDefault(5, Just(0)) != 0 {
@@ -82,3 +88,131 @@ func TestBind(t *testing.T) {
 		t.Error("chained function failed")
 	}
 }
+
+func TestAll(t *testing.T) {
+	for x := range All[int](Just(1)) {
+		if x != 1 {
+			t.Error("on Just")
+		}
+	}
+
+	for range All[int](Nothing[int]()) {
+		t.Error("on Nothing")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(Just(1))
+	if err != nil || string(data) != "1" {
+		t.Errorf("Just encodes as its value, got %s", data)
+	}
+
+	data, err = json.Marshal(Nothing[int]())
+	if err != nil || string(data) != "null" {
+		t.Errorf("Nothing encodes as null, got %s", data)
+	}
+
+	if m, err := FromJSON[int]([]byte("1")); err != nil || m != Just(1) {
+		t.Error("Just round trip")
+	}
+
+	if m, err := FromJSON[int]([]byte("null")); err != nil || m != Nothing[int]() {
+		t.Error("Nothing round trip")
+	}
+
+	if _, err := FromJSON[int]([]byte(`"one"`)); err == nil {
+		t.Error("Decoding the wrong type")
+	}
+
+	type record struct {
+		Name Maybe[string] `json:"name"`
+	}
+	data, _ = json.Marshal(record{Name: Nothing[string]()})
+	if string(data) != `{"name":null}` {
+		t.Errorf("Nothing as a field, got %s", data)
+	}
+
+	var plain tuple.Tuple[int, Maybe[string]]
+	if err := json.Unmarshal([]byte(`[1,"x"]`), &plain); err == nil {
+		t.Error("a Maybe element cannot be decoded")
+	}
+
+	var pair tuple.Tuple[int, JSON[string]]
+	if err := json.Unmarshal([]byte(`[1,"x"]`), &pair); err != nil || tuple.Second(pair).Maybe() != Just("x") {
+		t.Error("JSON element round trip")
+	}
+
+	var decoded struct {
+		Name JSON[string] `json:"name"`
+		Nick JSON[string] `json:"nick"`
+		Age  JSON[int]    `json:"age"`
+	}
+	decoded.Nick = AsJSON[string](Just("old"))
+	if err := json.Unmarshal([]byte(`{"name":"Tom","nick":null}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Name.Maybe() != Just("Tom") || decoded.Nick.Maybe() != Nothing[string]() || decoded.Age.Maybe() != Nothing[int]() {
+		t.Error("JSON fields decode null and missing values to Nothing")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	describe := func(m Maybe[int]) string {
+		return Match(m, func(x int) string { return "just" }, func() string { return "nothing" })
+	}
+
+	if describe(Just(1)) != "just" {
+		t.Error("on Just")
+	}
+
+	if describe(Nothing[int]()) != "nothing" {
+		t.Error("on Nothing")
+	}
+}
+
+func TestGet(t *testing.T) {
+	if x, ok := Get[int](Just(1)); !ok || x != 1 {
+		t.Error("on Just")
+	}
+
+	if x, ok := Get[int](Nothing[int]()); ok || x != 0 {
+		t.Error("on Nothing")
+	}
+}
+
+func TestMapN(t *testing.T) {
+	sum3 := func(a, b, c int) int { return a + b + c }
+	sum4 := func(a, b, c, d int) int { return a + b + c + d }
+	sum5 := func(a, b, c, d, e int) int { return a + b + c + d + e }
+	one := Just(1)
+	none := Nothing[int]()
+
+	if Map3(sum3, one, one, one) != Just(3) || Map3(sum3, one, none, one) != none {
+		t.Error("map3")
+	}
+
+	if Map4(sum4, one, one, one, one) != Just(4) || Map4(sum4, one, one, one, none) != none {
+		t.Error("map4")
+	}
+
+	if Map5(sum5, one, one, one, one, one) != Just(5) || Map5(sum5, none, one, one, one, one) != none {
+		t.Error("map5")
+	}
+}
+
+func TestNilsFirst(t *testing.T) {
+	first := NilsFirst(nub.Natural[int]())
+	last := NilsLast(nub.Natural[int]())
+
+	if first(Nothing[int](), Just(1)) != nub.LT || first(Just(1), Nothing[int]()) != nub.GT {
+		t.Error("NilsFirst orders Nothing before Just")
+	}
+
+	if last(Nothing[int](), Just(1)) != nub.GT || last(Just(1), Nothing[int]()) != nub.LT {
+		t.Error("NilsLast orders Nothing after Just")
+	}
+
+	if first(Just(1), Just(2)) != nub.LT || first(Nothing[int](), Nothing[int]()) != nub.EQ {
+		t.Error("Just values use the inner comparator")
+	}
+}
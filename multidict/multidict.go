@@ -0,0 +1,128 @@
+package multidict
+
+import (
+	"github.com/obiloud/curry-go/dict"
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+// A dictionary that stores several values per key. Keys are kept sorted as in
+// `dict.Dict`, and the values under a key keep the order they were inserted
+// in. A key is present only while it has at least one value.
+// Multi-dictionaries cannot be compared with `==`; use Equal.
+type MultiDict[A nub.Ord, B any] struct {
+	dict dict.Dict[A, list.List[B]]
+}
+
+// Convert a multi-dictionary into a string.
+func (m MultiDict[A, B]) String() string {
+	return m.dict.String()
+}
+
+// Create an empty multi-dictionary.
+func Empty[A nub.Ord, B any]() MultiDict[A, B] {
+	return MultiDict[A, B]{dict: dict.Empty[A, list.List[B]]()}
+}
+
+// Determine if a multi-dictionary is empty.
+func IsEmpty[A nub.Ord, B any](m MultiDict[A, B]) bool {
+	return dict.IsEmpty(m.dict)
+}
+
+// Determine if two multi-dictionaries hold the same values, in the same order,
+// under the same keys.
+func Equal[A nub.Ord, B comparable](a MultiDict[A, B], b MultiDict[A, B]) bool {
+	return dict.Equal(a.dict, b.dict)
+}
+
+// Determine the number of values in a multi-dictionary, counting every value
+// under every key.
+func Size[A nub.Ord, B any](m MultiDict[A, B]) int {
+	return dict.FoldL(func(_ A, values list.List[B], acc int) int {
+		return acc + list.Length[B](values)
+	}, 0, m.dict)
+}
+
+// Determine if a key has any values.
+func Member[A nub.Ord, B any](key A, m MultiDict[A, B]) bool {
+	return dict.Member(key, m.dict)
+}
+
+// Get all of the keys, sorted from lowest to highest.
+func Keys[A nub.Ord, B any](m MultiDict[A, B]) list.List[A] {
+	return dict.Keys(m.dict)
+}
+
+// Get all of the values under a key, in insertion order. Returns an empty list
+// when the key is missing.
+func GetAll[A nub.Ord, B any](key A, m MultiDict[A, B]) list.List[B] {
+	return maybe.WithDefault(list.Nil[B](), dict.Get(key, m.dict))
+}
+
+// Add a value after the values already stored under a key.
+func Insert[A nub.Ord, B any](key A, value B, m MultiDict[A, B]) MultiDict[A, B] {
+	return InsertAll(key, list.Singleton(value), m)
+}
+
+// Add a list of values after the values already stored under a key.
+func InsertAll[A nub.Ord, B any](key A, values list.List[B], m MultiDict[A, B]) MultiDict[A, B] {
+	if list.IsEmpty[B](values) {
+		return m
+	}
+	return MultiDict[A, B]{dict: dict.InsertWith(list.Append[B], key, values, m.dict)}
+}
+
+// Remove a key and all of its values.
+func Remove[A nub.Ord, B any](key A, m MultiDict[A, B]) MultiDict[A, B] {
+	return MultiDict[A, B]{dict: dict.Remove(key, m.dict)}
+}
+
+// Remove the first occurrence of a value under a key. The key is removed with
+// its last value. If the value is not found, no changes are made.
+func RemoveOne[A nub.Ord, B comparable](key A, value B, m MultiDict[A, B]) MultiDict[A, B] {
+	values := GetAll(key, m)
+	i := list.ElemIndex(value, values)
+	if i.IsNothing() {
+		return m
+	}
+	rest := list.RemoveAt[B](maybe.WithDefault(0, i), values)
+	if list.IsEmpty[B](rest) {
+		return Remove(key, m)
+	}
+	return MultiDict[A, B]{dict: dict.Insert(key, rest, m.dict)}
+}
+
+// Fold over every key-value pair, from lowest key to highest key and in
+// insertion order under each key.
+func FoldL[A nub.Ord, B any, C any](fn func(A, B, C) C, acc C, m MultiDict[A, B]) C {
+	return dict.FoldL(func(key A, values list.List[B], acc C) C {
+		return list.FoldL(func(value B, acc C) C {
+			return fn(key, value, acc)
+		}, acc, values)
+	}, acc, m.dict)
+}
+
+// Convert an association list into a multi-dictionary, keeping every value.
+func FromList[A nub.Ord, B any](ls list.List[tuple.Tuple[A, B]]) MultiDict[A, B] {
+	groups := dict.GroupBy(tuple.First[A, B], ls)
+	return MultiDict[A, B]{dict: dict.Map(func(pairs list.List[tuple.Tuple[A, B]]) list.List[B] {
+		return list.Map(tuple.Second[A, B], pairs)
+	}, groups)}
+}
+
+// Convert a multi-dictionary into an association list with one pair per value,
+// in the order of FoldL.
+func Flatten[A nub.Ord, B any](m MultiDict[A, B]) list.List[tuple.Tuple[A, B]] {
+	return dict.FoldR(func(key A, values list.List[B], acc list.List[tuple.Tuple[A, B]]) list.List[tuple.Tuple[A, B]] {
+		return list.FoldR(func(value B, acc list.List[tuple.Tuple[A, B]]) list.List[tuple.Tuple[A, B]] {
+			return list.Cons(tuple.Pair(key, value), acc)
+		}, acc, values)
+	}, list.Nil[tuple.Tuple[A, B]](), m.dict)
+}
+
+// Get the underlying dictionary from keys to their non-empty value lists.
+func ToDict[A nub.Ord, B any](m MultiDict[A, B]) dict.Dict[A, list.List[B]] {
+	return m.dict
+}
@@ -0,0 +1,73 @@
+package multidict
+
+import (
+	"testing"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+func TestBuild(t *testing.T) {
+	m := Insert("b", 3, Insert("a", 2, Insert("a", 1, Empty[string, int]())))
+
+	if GetAll("a", m) != list.FromSlice([]int{1, 2}) || GetAll("z", m) != list.Nil[int]() {
+		t.Error("GetAll keeps insertion order")
+	}
+
+	if Size(m) != 3 || Keys(m) != list.FromSlice([]string{"a", "b"}) || !Member("b", m) {
+		t.Error("Size / Keys / Member")
+	}
+
+	m = InsertAll("a", list.FromSlice([]int{4, 1}), m)
+	if GetAll("a", m) != list.FromSlice([]int{1, 2, 4, 1}) {
+		t.Error("InsertAll appends")
+	}
+
+	if !Equal(InsertAll("c", list.Nil[int](), m), m) {
+		t.Error("InsertAll with no values does not add the key")
+	}
+
+	if GetAll("a", RemoveOne("a", 1, m)) != list.FromSlice([]int{2, 4, 1}) {
+		t.Error("RemoveOne removes the first occurrence")
+	}
+
+	if !Equal(RemoveOne("a", 9, m), m) || !Equal(RemoveOne("z", 1, m), m) {
+		t.Error("RemoveOne of a missing value")
+	}
+
+	if Member("b", RemoveOne("b", 3, m)) {
+		t.Error("RemoveOne drops the key with its last value")
+	}
+
+	if Member("a", Remove("a", m)) || !IsEmpty(Remove("b", Remove("a", m))) {
+		t.Error("Remove")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	pairs := list.FromSlice([]tuple.Tuple[string, int]{
+		tuple.Pair("b", 1),
+		tuple.Pair("a", 2),
+		tuple.Pair("b", 3),
+		tuple.Pair("a", 4),
+	})
+	m := FromList[string, int](pairs)
+
+	expected := list.FromSlice([]tuple.Tuple[string, int]{
+		tuple.Pair("a", 2),
+		tuple.Pair("a", 4),
+		tuple.Pair("b", 1),
+		tuple.Pair("b", 3),
+	})
+	if Flatten(m) != expected {
+		t.Errorf("Flatten goes by key, then insertion order, got %v", Flatten(m))
+	}
+
+	if FoldL(func(_ string, v int, acc int) int { return acc*10 + v }, 0, m) != 2413 {
+		t.Error("FoldL")
+	}
+
+	if Size(m) != 4 || ToDict(m).String() != `[("a", [2, 4]), ("b", [1, 3])]` {
+		t.Errorf("ToDict, got %s", ToDict(m))
+	}
+}
@@ -96,6 +96,78 @@ func Compare[T Ord](x T, y T) Order {
 	return EQ
 }
 
+//----------------------------------------------------------------
+// comparators
+//----------------------------------------------------------------
+
+// A Comparator orders two values. Any func(T, T) Order can be used where a
+// Comparator is expected, and a Comparator can be passed to SortWith.
+type Comparator[T any] func(T, T) Order
+
+func Natural[T Ord]() Comparator[T] {
+	return Compare[T]
+}
+
+func By[T any, K Ord](key func(T) K) Comparator[T] {
+	return func(x T, y T) Order {
+		return Compare(key(x), key(y))
+	}
+}
+
+func Reverse[T any](c Comparator[T]) Comparator[T] {
+	return func(x T, y T) Order {
+		return c(y, x)
+	}
+}
+
+// Break ties of the first comparator with the second.
+func ThenWith[T any](c Comparator[T], next Comparator[T]) Comparator[T] {
+	return func(x T, y T) Order {
+		if o := c(x, y); o != EQ {
+			return o
+		}
+		return next(x, y)
+	}
+}
+
+func ThenBy[T any, K Ord](c Comparator[T], key func(T) K) Comparator[T] {
+	return ThenWith(c, By(key))
+}
+
+// Convert a comparison in the standard cmp.Compare convention, which returns
+// a negative number, zero or a positive number.
+func FromCmp[T any](fn func(T, T) int) Comparator[T] {
+	return func(x T, y T) Order {
+		return FromInt(fn(x, y))
+	}
+}
+
+func ToCmp[T any](c Comparator[T]) func(T, T) int {
+	return func(x T, y T) int {
+		return c(x, y).Int()
+	}
+}
+
+func FromInt(n int) Order {
+	if n < 0 {
+		return LT
+	}
+	if n > 0 {
+		return GT
+	}
+	return EQ
+}
+
+func (o Order) Int() int {
+	switch o {
+	case LT:
+		return -1
+	case GT:
+		return 1
+	}
+	return 0
+}
+
 //----------------------------------------------------------------
 // equal
 //----------------------------------------------------------------
@@ -116,14 +188,14 @@ func Negate[T Num](x T) T {
 // min / max
 //----------------------------------------------------------------
 
-func Min[T Num](x T, y T) T {
+func Min[T Ord](x T, y T) T {
 	if x < y {
 		return x
 	}
 	return y
 }
 
-func Max[T Num](x T, y T) T {
+func Max[T Ord](x T, y T) T {
 	if x > y {
 		return x
 	}
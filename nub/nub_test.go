@@ -0,0 +1,59 @@
+package nub
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestComparator(t *testing.T) {
+	byAge := By(func(p person) int { return p.age })
+
+	if byAge(person{"a", 30}, person{"b", 40}) != LT {
+		t.Error("By compares on the key")
+	}
+
+	if Reverse(byAge)(person{"a", 30}, person{"b", 40}) != GT {
+		t.Error("Reverse flips the order")
+	}
+
+	byAgeThenName := ThenBy(byAge, func(p person) string { return p.name })
+	if byAgeThenName(person{"b", 30}, person{"a", 30}) != GT {
+		t.Error("ThenBy breaks ties on the second key")
+	}
+	if byAgeThenName(person{"b", 20}, person{"a", 30}) != LT {
+		t.Error("ThenBy keeps the first key when it decides")
+	}
+
+	if ThenWith(Natural[int](), Reverse(Natural[int]()))(1, 1) != EQ {
+		t.Error("ThenWith is EQ when both comparators are")
+	}
+}
+
+func TestCmpConvention(t *testing.T) {
+	caseless := FromCmp(func(x string, y string) int {
+		return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+	})
+
+	if caseless("ABC", "abc") != EQ || caseless("a", "B") != LT || caseless("b", "A") != GT {
+		t.Error("FromCmp maps negative, zero and positive to LT, EQ and GT")
+	}
+
+	xs := []int{3, 1, 2}
+	slices.SortFunc(xs, ToCmp(Reverse(Natural[int]())))
+	if !slices.Equal(xs, []int{3, 2, 1}) {
+		t.Error("ToCmp works with slices.SortFunc")
+	}
+
+	for _, pair := range [][2]int{{1, 2}, {2, 2}, {3, 2}} {
+		if Compare(pair[0], pair[1]).Int() != cmp.Compare(pair[0], pair[1]) {
+			t.Error("Order.Int agrees with cmp.Compare")
+		}
+	}
+}
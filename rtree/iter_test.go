@@ -0,0 +1,53 @@
+package rtree
+
+import (
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPreOrder(t *testing.T) {
+	if strings.Join(slices.Collect(PreOrder(interestingTree)), "") != "abekcfgdhij" {
+		t.Error("Pre-order visits a node before its children")
+	}
+
+	if strings.Join(slices.Collect(PreOrder(noChildTree)), "") != "a" {
+		t.Error("Pre-order of a single node")
+	}
+}
+
+func TestPostOrder(t *testing.T) {
+	if strings.Join(slices.Collect(PostOrder(interestingTree)), "") != "kebfgchijda" {
+		t.Error("Post-order visits a node after its children")
+	}
+
+	if strings.Join(slices.Collect(PostOrder(noChildTree)), "") != "a" {
+		t.Error("Post-order of a single node")
+	}
+}
+
+func TestLevelOrder(t *testing.T) {
+	if strings.Join(slices.Collect(LevelOrder(interestingTree)), "") != "abcdefghijk" {
+		t.Error("Level-order visits the tree breadth first")
+	}
+}
+
+func TestIteratorBreak(t *testing.T) {
+	for name, seq := range map[string]func(RTree[string]) iter.Seq[string]{
+		"PreOrder":   PreOrder[string],
+		"PostOrder":  PostOrder[string],
+		"LevelOrder": LevelOrder[string],
+	} {
+		visited := 0
+		for range seq(interestingTree) {
+			visited++
+			if visited == 3 {
+				break
+			}
+		}
+		if visited != 3 {
+			t.Errorf("%s stops when the loop breaks", name)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package rtree
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(singleChildTree)
+	if err != nil || string(data) != `{"data":"a","children":[{"data":"b","children":[]}]}` {
+		t.Errorf("Tree encodes as data and children, got %s", data)
+	}
+
+	data, err = json.Marshal(interestingTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded RTree[string]
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != interestingTree {
+		t.Error("Tree round trip")
+	}
+
+	if err := json.Unmarshal([]byte(`{"data":1}`), &decoded); err == nil {
+		t.Error("Tree with the wrong data type")
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &decoded); err != nil || decoded != interestingTree {
+		t.Error("null leaves the tree unchanged")
+	}
+}
@@ -1,8 +1,11 @@
 package rtree
 
 import (
+	"encoding/json"
 	"fmt"
+	"iter"
 
+	"github.com/obiloud/curry-go/either"
 	"github.com/obiloud/curry-go/list"
 	"github.com/obiloud/curry-go/maybe"
 	"github.com/obiloud/curry-go/nub"
@@ -19,6 +22,31 @@ func (t RTree[T]) String() string {
 	return fmt.Sprintf("Tree %s %s", util.Stringify(t.Data), t.Children.String())
 }
 
+type rtreeJSON[T any] struct {
+	Data     T          `json:"data"`
+	Children []RTree[T] `json:"children"`
+}
+
+func (t RTree[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rtreeJSON[T]{
+		Data:     t.Data,
+		Children: list.ToSlice[RTree[T]](t.Children),
+	})
+}
+
+func (t *RTree[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var decoded rtreeJSON[T]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	t.Data = decoded.Data
+	t.Children = list.FromSlice(decoded.Children)
+	return nil
+}
+
 func InsertChild[T any](child RTree[T], tree RTree[T]) RTree[T] {
 	tree.Children = list.Cons(child, tree.Children)
 	return tree
@@ -52,6 +80,76 @@ func Flatten[T any](tree RTree[T]) list.List[T] {
 	return FoldR(cons, list.Nil[T](), tree)
 }
 
+func PreOrder[T any](tree RTree[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		stack := []RTree[T]{tree}
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(t.Data) {
+				return
+			}
+			children := list.ToSlice[RTree[T]](t.Children)
+			for i := len(children) - 1; i >= 0; i-- {
+				stack = append(stack, children[i])
+			}
+		}
+	}
+}
+
+func PostOrder[T any](tree RTree[T]) iter.Seq[T] {
+	type frame struct {
+		tree    RTree[T]
+		pending list.List[RTree[T]]
+	}
+	return func(yield func(T) bool) {
+		stack := []frame{{tree: tree, pending: tree.Children}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if list.IsEmpty[RTree[T]](top.pending) {
+				if !yield(top.tree.Data) {
+					return
+				}
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			child := maybe.WithDefault(top.tree, list.Head[RTree[T]](top.pending))
+			top.pending = list.Tail[RTree[T]](top.pending)
+			stack = append(stack, frame{tree: child, pending: child.Children})
+		}
+	}
+}
+
+func LevelOrder[T any](tree RTree[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		queue := []RTree[T]{tree}
+		for len(queue) > 0 {
+			t := queue[0]
+			queue = queue[1:]
+			if !yield(t.Data) {
+				return
+			}
+			queue = append(queue, list.ToSlice[RTree[T]](t.Children)...)
+		}
+	}
+}
+
+func MaximumBy[A any, B nub.Ord](key func(A) B, tree RTree[A]) maybe.Maybe[A] {
+	return list.MaximumBy(key, Flatten(tree))
+}
+
+func MinimumBy[A any, B nub.Ord](key func(A) B, tree RTree[A]) maybe.Maybe[A] {
+	return list.MinimumBy(key, Flatten(tree))
+}
+
+func MaximumWith[T any](compare func(T, T) nub.Order, tree RTree[T]) maybe.Maybe[T] {
+	return list.MaximumWith(compare, Flatten(tree))
+}
+
+func MinimumWith[T any](compare func(T, T) nub.Order, tree RTree[T]) maybe.Maybe[T] {
+	return list.MinimumWith(compare, Flatten(tree))
+}
+
 func TuplesOfDatumAndFlatChildren[T any](tree RTree[T]) list.List[tuple.Tuple[T, list.List[T]]] {
 	return list.Append[tuple.Tuple[T, list.List[T]]](
 		list.Singleton(
@@ -137,6 +235,44 @@ func IndexedMap[A, B any](fn func(int, A) B, tree RTree[A]) maybe.Maybe[RTree[B]
 	return MapListOverTree(fn, list.Range(0, Length(tree)-1), tree)
 }
 
+func TraverseMaybe[A, B any](fn func(A) maybe.Maybe[B], tree RTree[A]) maybe.Maybe[RTree[B]] {
+	data, ok := maybe.Get[B](fn(tree.Data))
+	if !ok {
+		return maybe.Nothing[RTree[B]]()
+	}
+	transformTree := func(c RTree[A]) maybe.Maybe[RTree[B]] {
+		return TraverseMaybe(fn, c)
+	}
+	return maybe.Map(func(children list.List[RTree[B]]) RTree[B] {
+		return RTree[B]{
+			Data:     data,
+			Children: children,
+		}
+	}, list.TraverseMaybe(transformTree, tree.Children))
+}
+
+func SequenceMaybe[T any](tree RTree[maybe.Maybe[T]]) maybe.Maybe[RTree[T]] {
+	return TraverseMaybe(nub.Id[maybe.Maybe[T]], tree)
+}
+
+func TraverseEither[A, B, E any](fn func(A) either.Either[E, B], tree RTree[A]) either.Either[E, RTree[B]] {
+	transformTree := func(c RTree[A]) either.Either[E, RTree[B]] {
+		return TraverseEither(fn, c)
+	}
+	return either.Bind(func(data B) either.Either[E, RTree[B]] {
+		return either.Map[E](func(children list.List[RTree[B]]) RTree[B] {
+			return RTree[B]{
+				Data:     data,
+				Children: children,
+			}
+		}, either.Traverse(transformTree, tree.Children))
+	}, fn(tree.Data))
+}
+
+func SequenceEither[T, E any](tree RTree[either.Either[E, T]]) either.Either[E, RTree[T]] {
+	return TraverseEither(nub.Id[either.Either[E, T]], tree)
+}
+
 func Filter[T any](predicate func(T) bool, tree RTree[T]) maybe.Maybe[RTree[T]] {
 	if predicate(tree.Data) {
 		return maybe.Just(RTree[T]{
@@ -167,6 +303,8 @@ func FilterWithChildPresedence[T any](predicate func(T) bool, tree RTree[T]) may
 	})
 }
 
+// Sort the children of every node by a derived property of their data. The
+// sort is stable: siblings with equal properties keep their original order.
 func SortBy[A any, B nub.Ord](fn func(A) B, tree RTree[A]) RTree[A] {
 	sortedChildren := list.Map(nub.Curry(SortBy[A, B])(fn), list.SortBy(func(t RTree[A]) B {
 		return fn(t.Data)
@@ -178,6 +316,8 @@ func SortBy[A any, B nub.Ord](fn func(A) B, tree RTree[A]) RTree[A] {
 	}
 }
 
+// Sort the children of every node with a custom comparison of their data. The
+// sort is stable: siblings that compare as EQ keep their original order.
 func SortWith[T any](comperator func(T, T) nub.Order, tree RTree[T]) RTree[T] {
 	sortedChildren := list.Map(nub.Curry(SortWith[T])(comperator), list.SortWith(func(a RTree[T], b RTree[T]) nub.Order {
 		return comperator(a.Data, b.Data)
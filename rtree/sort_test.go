@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
 	"github.com/obiloud/curry-go/nub"
 )
 
@@ -151,3 +152,41 @@ func TestSort(t *testing.T) {
 		t.Error("Sorting with a Tree with a reversed comperator reverse-sorts a Tree")
 	}
 }
+
+func TestSortStable(t *testing.T) {
+	leaf := func(x string) RTree[string] {
+		return RTree[string]{Data: x, Children: list.Nil[RTree[string]]()}
+	}
+
+	tree := RTree[string]{
+		Data:     "root",
+		Children: list.FromSlice([]RTree[string]{leaf("b2"), leaf("a1"), leaf("b1"), leaf("a2")}),
+	}
+
+	expected := RTree[string]{
+		Data:     "root",
+		Children: list.FromSlice([]RTree[string]{leaf("a1"), leaf("a2"), leaf("b2"), leaf("b1")}),
+	}
+
+	firstLetter := func(x string) string {
+		return x[:1]
+	}
+
+	if SortBy(firstLetter, tree) != expected {
+		t.Error("SortBy keeps siblings with equal keys in their original order")
+	}
+
+	if SortWith(func(x string, y string) nub.Order { return nub.Compare(firstLetter(x), firstLetter(y)) }, tree) != expected {
+		t.Error("SortWith keeps siblings with equal keys in their original order")
+	}
+}
+
+func TestExtremes(t *testing.T) {
+	if MaximumBy(nub.Id[string], interestingTree) != maybe.Just("k") || MinimumBy(nub.Id[string], interestingTree) != maybe.Just("a") {
+		t.Error("MaximumBy / MinimumBy over every node")
+	}
+
+	if MaximumWith(nub.Compare[string], deepTree) != maybe.Just("d") || MinimumWith(nub.Compare[string], deepTree) != maybe.Just("a") {
+		t.Error("MaximumWith / MinimumWith over every node")
+	}
+}
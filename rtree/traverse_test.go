@@ -0,0 +1,50 @@
+package rtree
+
+import (
+	"testing"
+
+	"github.com/obiloud/curry-go/either"
+	"github.com/obiloud/curry-go/maybe"
+)
+
+func TestTraverse(t *testing.T) {
+	notK := func(x string) maybe.Maybe[string] {
+		if x == "k" {
+			return maybe.Nothing[string]()
+		}
+		return maybe.Just(x)
+	}
+
+	if TraverseMaybe(notK, multiChildTree) != maybe.Just(multiChildTree) {
+		t.Error("TraverseMaybe all Just")
+	}
+
+	if TraverseMaybe(notK, interestingTree) != maybe.Nothing[RTree[string]]() {
+		t.Error("TraverseMaybe with a Nothing deep in the tree")
+	}
+
+	if SequenceMaybe[string](Map(maybe.Just[string], deepTree)) != maybe.Just(deepTree) {
+		t.Error("SequenceMaybe")
+	}
+
+	visited := ""
+	beforeF := func(x string) either.Either[string, string] {
+		visited += x
+		if x == "f" || x == "h" {
+			return either.FromLeft[string, string](x)
+		}
+		return either.FromRight[string](x)
+	}
+
+	if TraverseEither(beforeF, interestingTree) != either.FromLeft[string, RTree[string]]("f") || visited != "abekcf" {
+		t.Error("TraverseEither stops at the first Left in pre-order")
+	}
+
+	if TraverseEither(beforeF, multiChildTree) != either.FromRight[string](multiChildTree) {
+		t.Error("TraverseEither all Right")
+	}
+
+	if SequenceEither[string, string](Map(either.FromRight[string, string], deepTree)) != either.FromRight[string](deepTree) {
+		t.Error("SequenceEither")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/obiloud/curry-go/list"
 	"github.com/obiloud/curry-go/maybe"
 	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/tuple"
 	"github.com/obiloud/curry-go/util"
 )
 
@@ -62,21 +63,23 @@ type split[T any] struct {
 }
 
 func splitOnIndex[T any](n int, xs list.List[RTree[T]]) maybe.Maybe[split[T]] {
-	before := list.Take[RTree[T]](n, xs)
+	if n < 0 {
+		return maybe.Nothing[split[T]]()
+	}
 
-	focus := list.Head[RTree[T]](list.Drop[RTree[T]](n, xs))
+	parts := list.SplitAt[RTree[T]](n, xs)
 
-	after := list.Drop[RTree[T]](n+1, xs)
+	rest := tuple.Second(parts)
 
 	toSplit := func(f RTree[T]) split[T] {
 		return split[T]{
-			before: before,
+			before: tuple.First(parts),
 			focus:  f,
-			after:  after,
+			after:  list.Tail[RTree[T]](rest),
 		}
 	}
 
-	return maybe.Map(toSplit, focus)
+	return maybe.Map(toSplit, list.Head[RTree[T]](rest))
 }
 
 // Move up relative to the current Zipper focus. This allows navigation from a
@@ -0,0 +1,147 @@
+package set
+
+import (
+	"iter"
+
+	"github.com/obiloud/curry-go/dict"
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/nub"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+// A set of unique values. Sets are dictionaries without values, so they share
+// the ordering and complexity of `dict.Dict`: Insert, Remove and Member run in
+// O(log n), and folds and lists go from lowest to highest.
+//
+// As with `dict.Dict`, `==` compares the shape of the underlying tree rather
+// than the elements. Use Equal instead.
+type Set[A nub.Ord] struct {
+	dict dict.Dict[A, struct{}]
+}
+
+// Convert a set into a string.
+func (set Set[A]) String() string {
+	return ToList(set).String()
+}
+
+// Create an empty set.
+func Empty[A nub.Ord]() Set[A] {
+	return Set[A]{dict: dict.Empty[A, struct{}]()}
+}
+
+// Create a set with one value.
+func Singleton[A nub.Ord](value A) Set[A] {
+	return Set[A]{dict: dict.Singleton(value, struct{}{})}
+}
+
+// Insert a value into a set.
+func Insert[A nub.Ord](value A, set Set[A]) Set[A] {
+	return Set[A]{dict: dict.Insert(value, struct{}{}, set.dict)}
+}
+
+// Remove a value from a set. If the value is not found, no changes are made.
+func Remove[A nub.Ord](value A, set Set[A]) Set[A] {
+	return Set[A]{dict: dict.Remove(value, set.dict)}
+}
+
+// Determine if a set is empty.
+func IsEmpty[A nub.Ord](set Set[A]) bool {
+	return dict.IsEmpty(set.dict)
+}
+
+// Determine if a value is in a set.
+func Member[A nub.Ord](value A, set Set[A]) bool {
+	return dict.Member(value, set.dict)
+}
+
+// Determine if two sets hold the same elements.
+func Equal[A nub.Ord](a Set[A], b Set[A]) bool {
+	return dict.Equal(a.dict, b.dict)
+}
+
+// Determine the number of elements in a set.
+func Size[A nub.Ord](set Set[A]) int {
+	return dict.Size(set.dict)
+}
+
+// COMBINE
+
+// Get the union of two sets. Keep all values.
+func Union[A nub.Ord](a Set[A], b Set[A]) Set[A] {
+	return Set[A]{dict: dict.Union(a.dict, b.dict)}
+}
+
+// Get the intersection of two sets. Keeps values that appear in both sets.
+func Intersect[A nub.Ord](a Set[A], b Set[A]) Set[A] {
+	return Set[A]{dict: dict.Intersect(a.dict, b.dict)}
+}
+
+// Get the difference between the first set and the second. Keeps values that
+// do not appear in the second set.
+func Diff[A nub.Ord](a Set[A], b Set[A]) Set[A] {
+	return Set[A]{dict: dict.Diff(a.dict, b.dict)}
+}
+
+// TRANSFORM
+
+// Map a function onto a set, creating a new set with no duplicates.
+func Map[A nub.Ord, B nub.Ord](fn func(A) B, set Set[A]) Set[B] {
+	return FoldL(func(value A, acc Set[B]) Set[B] {
+		return Insert(fn(value), acc)
+	}, Empty[B](), set)
+}
+
+// Fold over the values in a set, in order from lowest to highest.
+func FoldL[A nub.Ord, B any](fn func(A, B) B, acc B, set Set[A]) B {
+	return dict.FoldL(func(value A, _ struct{}, acc B) B {
+		return fn(value, acc)
+	}, acc, set.dict)
+}
+
+// Fold over the values in a set, in order from highest to lowest.
+func FoldR[A nub.Ord, B any](fn func(A, B) B, acc B, set Set[A]) B {
+	return dict.FoldR(func(value A, _ struct{}, acc B) B {
+		return fn(value, acc)
+	}, acc, set.dict)
+}
+
+// Only keep elements that pass the given test.
+func Filter[A nub.Ord](isGood func(A) bool, set Set[A]) Set[A] {
+	return Set[A]{dict: dict.Filter(func(value A, _ struct{}) bool {
+		return isGood(value)
+	}, set.dict)}
+}
+
+// Create two new sets. The first contains all the elements that passed the
+// given test, and the second contains all the elements that did not.
+func Partition[A nub.Ord](isGood func(A) bool, set Set[A]) tuple.Tuple[Set[A], Set[A]] {
+	parts := dict.Partition(func(value A, _ struct{}) bool {
+		return isGood(value)
+	}, set.dict)
+	return tuple.Pair(Set[A]{dict: tuple.First(parts)}, Set[A]{dict: tuple.Second(parts)})
+}
+
+// LISTS
+
+// Convert a list into a set, removing any duplicates.
+func FromList[A nub.Ord](ls list.List[A]) Set[A] {
+	return list.FoldL(Insert[A], Empty[A](), ls)
+}
+
+// Convert a set into a list, sorted from lowest to highest.
+func ToList[A nub.Ord](set Set[A]) list.List[A] {
+	return dict.Keys(set.dict)
+}
+
+// ITERATORS
+
+// An iterator over the values in a set, from lowest to highest.
+func All[A nub.Ord](set Set[A]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for value := range dict.All(set.dict) {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package set
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+func fromInts(xs ...int) Set[int] {
+	return FromList[int](list.FromSlice(xs))
+}
+
+func TestBuild(t *testing.T) {
+	if !IsEmpty(Empty[int]()) || Size(Empty[int]()) != 0 {
+		t.Error("Empty")
+	}
+
+	if !Equal(Singleton(1), Insert(1, Empty[int]())) {
+		t.Error("Singleton")
+	}
+
+	if !Equal(Insert(1, Singleton(1)), Singleton(1)) {
+		t.Error("Insert is idempotent")
+	}
+
+	if !IsEmpty(Remove(1, Singleton(1))) || !Equal(Remove(2, Singleton(1)), Singleton(1)) {
+		t.Error("Remove")
+	}
+
+	s := fromInts(3, 1, 2, 3, 1)
+	if Size(s) != 3 || ToList(s) != list.Range(1, 3) {
+		t.Error("FromList removes duplicates and sorts")
+	}
+
+	if !Member(2, s) || Member(4, s) {
+		t.Error("Member")
+	}
+
+	if !Equal(fromInts(1, 2, 3, 4, 5, 6), fromInts(6, 5, 4, 3, 2, 1)) || Equal(s, fromInts(1, 2)) {
+		t.Error("Equal compares elements regardless of insertion order")
+	}
+}
+
+func TestCombine(t *testing.T) {
+	a := fromInts(1, 2, 3, 4)
+	b := fromInts(3, 4, 5)
+
+	if ToList(Union(a, b)) != list.Range(1, 5) {
+		t.Error("Union")
+	}
+
+	if ToList(Intersect(a, b)) != list.Range(3, 4) {
+		t.Error("Intersect")
+	}
+
+	if ToList(Diff(a, b)) != list.Range(1, 2) {
+		t.Error("Diff")
+	}
+}
+
+func TestTransform(t *testing.T) {
+	s := fromInts(1, 2, 3, 4, 5)
+
+	if ToList(Map(func(x int) int { return x / 2 }, s)) != list.Range(0, 2) {
+		t.Error("Map removes the new duplicates")
+	}
+
+	even := func(x int) bool { return x%2 == 0 }
+	if !Equal(Filter(even, s), fromInts(2, 4)) {
+		t.Error("Filter")
+	}
+
+	parts := Partition(even, s)
+	if !Equal(tuple.First(parts), fromInts(2, 4)) || !Equal(tuple.Second(parts), fromInts(1, 3, 5)) {
+		t.Error("Partition")
+	}
+
+	if FoldL(func(x int, acc []int) []int { return append(acc, x) }, nil, s)[0] != 1 {
+		t.Error("FoldL goes from lowest to highest")
+	}
+
+	if FoldR(func(x int, acc []int) []int { return append(acc, x) }, nil, s)[0] != 5 {
+		t.Error("FoldR goes from highest to lowest")
+	}
+
+	if !slices.Equal(slices.Collect(All(s)), []int{1, 2, 3, 4, 5}) {
+		t.Error("All")
+	}
+
+	if s.String() != "[1, 2, 3, 4, 5]" {
+		t.Errorf("String %s", s)
+	}
+}
@@ -0,0 +1,265 @@
+package stream
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/tuple"
+	"github.com/obiloud/curry-go/util"
+)
+
+// A possibly infinite sequence whose tail is computed on demand. Each tail is
+// evaluated at most once and then remembered, so walking the same stream
+// twice does not repeat the work.
+type Stream[T any] interface {
+	isCons() bool
+	String() string
+}
+
+type nilStream[T any] struct{}
+
+type consStream[T any] struct {
+	head T
+	tail *thunk[T]
+}
+
+// A memoized, suspended computation of the rest of a stream.
+type thunk[T any] struct {
+	once  sync.Once
+	done  atomic.Bool
+	fn    func() Stream[T]
+	value Stream[T]
+}
+
+func (t *thunk[T]) force() Stream[T] {
+	t.once.Do(func() {
+		t.value = t.fn()
+		t.fn = nil
+		t.done.Store(true)
+	})
+	return t.value
+}
+
+func (t *thunk[T]) forced() bool {
+	return t.done.Load()
+}
+
+func (n nilStream[T]) isCons() bool {
+	return false
+}
+
+func (n nilStream[T]) String() string {
+	return "Nil"
+}
+
+func (c consStream[T]) isCons() bool {
+	return true
+}
+
+// Only the part of the stream that has already been evaluated is shown.
+func (c consStream[T]) String() string {
+	strs := []string{util.Stringify(c.head)}
+	var s Stream[T] = c
+	for s.isCons() && s.(consStream[T]).tail.forced() {
+		s = s.(consStream[T]).tail.value
+		if s.isCons() {
+			strs = append(strs, util.Stringify(s.(consStream[T]).head))
+		}
+	}
+	if s.isCons() {
+		strs = append(strs, "...")
+	}
+	return fmt.Sprintf("[%s]", strings.Join(strs, ", "))
+}
+
+// CREATE
+
+// The empty stream.
+func Nil[T any]() Stream[T] {
+	return nilStream[T]{}
+}
+
+// Prepend a value to a stream whose rest is computed only when it is needed.
+func Cons[T any](head T, tail func() Stream[T]) Stream[T] {
+	return consStream[T]{head: head, tail: &thunk[T]{fn: tail}}
+}
+
+// A stream with a single element.
+func Singleton[T any](x T) Stream[T] {
+	return Cons(x, Nil[T])
+}
+
+// A finite stream with the elements of a list.
+func FromList[T any](ls list.List[T]) Stream[T] {
+	return maybe.WithDefault(Nil[T](), maybe.Map2(func(head T, tail list.List[T]) Stream[T] {
+		return Cons(head, func() Stream[T] {
+			return FromList[T](tail)
+		})
+	}, list.Head[T](ls), maybe.Just(list.Tail[T](ls))))
+}
+
+// An infinite stream of the seed followed by repeated applications of a
+// function: seed, fn(seed), fn(fn(seed)), ...
+func Iterate[T any](fn func(T) T, seed T) Stream[T] {
+	return Cons(seed, func() Stream[T] {
+		return Iterate(fn, fn(seed))
+	})
+}
+
+// An infinite stream of the same value.
+func Repeat[T any](x T) Stream[T] {
+	return Cons(x, func() Stream[T] {
+		return Repeat(x)
+	})
+}
+
+// Repeat the elements of a list forever. Cycling an empty list gives an
+// empty stream.
+func Cycle[T any](ls list.List[T]) Stream[T] {
+	if list.IsEmpty[T](ls) {
+		return Nil[T]()
+	}
+	return cycleHelp[T](ls, ls)
+}
+
+func cycleHelp[T any](rest list.List[T], ls list.List[T]) Stream[T] {
+	if list.IsEmpty[T](rest) {
+		rest = ls
+	}
+	return Cons(maybe.WithDefault(*new(T), list.Head[T](rest)), func() Stream[T] {
+		return cycleHelp[T](list.Tail[T](rest), ls)
+	})
+}
+
+// Build a stream from a seed. The function returns the next element and the
+// new seed, or `Nothing` to end the stream.
+func Unfold[S, T any](fn func(S) maybe.Maybe[tuple.Tuple[T, S]], seed S) Stream[T] {
+	return maybe.WithDefault(Nil[T](), maybe.Map(func(next tuple.Tuple[T, S]) Stream[T] {
+		return Cons(tuple.First(next), func() Stream[T] {
+			return Unfold(fn, tuple.Second(next))
+		})
+	}, fn(seed)))
+}
+
+// DECONSTRUCT
+
+// Determine if a stream is empty.
+func IsEmpty[T any](s Stream[T]) bool {
+	return !s.isCons()
+}
+
+// The first element of a stream.
+func Head[T any](s Stream[T]) maybe.Maybe[T] {
+	if s.isCons() {
+		return maybe.Just(s.(consStream[T]).head)
+	}
+	return maybe.Nothing[T]()
+}
+
+// Everything but the first element of a stream. Forces the tail.
+func Tail[T any](s Stream[T]) Stream[T] {
+	if s.isCons() {
+		return s.(consStream[T]).tail.force()
+	}
+	return s
+}
+
+// Take the first n elements of a stream into a list. Only those elements are
+// evaluated.
+func Take[T any](n int, s Stream[T]) list.List[T] {
+	slice := []T{}
+	for ; n > 0 && s.isCons(); n-- {
+		slice = append(slice, s.(consStream[T]).head)
+		if n > 1 {
+			s = s.(consStream[T]).tail.force()
+		}
+	}
+	return list.FromSlice(slice)
+}
+
+// Drop the first n elements of a stream.
+func Drop[T any](n int, s Stream[T]) Stream[T] {
+	for ; n > 0 && s.isCons(); n-- {
+		s = s.(consStream[T]).tail.force()
+	}
+	return s
+}
+
+// Evaluate a finite stream into a list. Never returns on an infinite stream.
+func ToList[T any](s Stream[T]) list.List[T] {
+	slice := []T{}
+	for ; s.isCons(); s = s.(consStream[T]).tail.force() {
+		slice = append(slice, s.(consStream[T]).head)
+	}
+	return list.FromSlice(slice)
+}
+
+// TRANSFORM
+
+// Apply a function to every element of a stream, lazily.
+func Map[A, B any](fn func(A) B, s Stream[A]) Stream[B] {
+	if !s.isCons() {
+		return Nil[B]()
+	}
+	c := s.(consStream[A])
+	return Cons(fn(c.head), func() Stream[B] {
+		return Map(fn, c.tail.force())
+	})
+}
+
+// Keep only the elements that pass the test. Looking for the next element of
+// an infinite stream with no further matches never returns.
+func Filter[T any](isGood func(T) bool, s Stream[T]) Stream[T] {
+	for s.isCons() && !isGood(s.(consStream[T]).head) {
+		s = s.(consStream[T]).tail.force()
+	}
+	if !s.isCons() {
+		return s
+	}
+	c := s.(consStream[T])
+	return Cons(c.head, func() Stream[T] {
+		return Filter(isGood, c.tail.force())
+	})
+}
+
+// Take elements while they pass the test, ending the stream at the first one
+// that does not.
+func TakeWhile[T any](isGood func(T) bool, s Stream[T]) Stream[T] {
+	if !s.isCons() || !isGood(s.(consStream[T]).head) {
+		return Nil[T]()
+	}
+	c := s.(consStream[T])
+	return Cons(c.head, func() Stream[T] {
+		return TakeWhile(isGood, c.tail.force())
+	})
+}
+
+// Drop elements while they pass the test.
+func DropWhile[T any](isGood func(T) bool, s Stream[T]) Stream[T] {
+	for s.isCons() && isGood(s.(consStream[T]).head) {
+		s = s.(consStream[T]).tail.force()
+	}
+	return s
+}
+
+// Pair up the elements of two streams. The result ends with the shorter one.
+func Zip[A, B any](xs Stream[A], ys Stream[B]) Stream[tuple.Tuple[A, B]] {
+	return Map2(tuple.Pair[A, B], xs, ys)
+}
+
+// Combine the elements of two streams with a function. The result ends with
+// the shorter one.
+func Map2[A, B, C any](fn func(A, B) C, xs Stream[A], ys Stream[B]) Stream[C] {
+	if !xs.isCons() || !ys.isCons() {
+		return Nil[C]()
+	}
+	x := xs.(consStream[A])
+	y := ys.(consStream[B])
+	return Cons(fn(x.head, y.head), func() Stream[C] {
+		return Map2(fn, x.tail.force(), y.tail.force())
+	})
+}
@@ -0,0 +1,127 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/tuple"
+)
+
+var naturals = Iterate(func(x int) int { return x + 1 }, 0)
+
+func TestCreate(t *testing.T) {
+	if Take[int](3, naturals) != list.Range(0, 2) {
+		t.Error("Iterate")
+	}
+
+	repeated := Repeat("a")
+	if Take[string](3, repeated) != list.Repeat(3, "a") {
+		t.Error("Repeat")
+	}
+
+	if repeated.String() != `["a", "a", "a", ...]` {
+		t.Errorf("a forced Repeat shows its evaluated prefix, got %s", repeated.String())
+	}
+
+	if Take[int](5, Cycle[int](list.Range(1, 2))) != list.FromSlice([]int{1, 2, 1, 2, 1}) {
+		t.Error("Cycle")
+	}
+
+	if !IsEmpty[int](Cycle[int](list.Nil[int]())) {
+		t.Error("Cycle empty")
+	}
+
+	if ToList[int](FromList[int](list.Range(1, 5))) != list.Range(1, 5) {
+		t.Error("FromList")
+	}
+
+	countdown := func(n int) maybe.Maybe[tuple.Tuple[int, int]] {
+		if n == 0 {
+			return maybe.Nothing[tuple.Tuple[int, int]]()
+		}
+		return maybe.Just(tuple.Pair(n, n-1))
+	}
+
+	if ToList[int](Unfold(countdown, 3)) != list.FromSlice([]int{3, 2, 1}) {
+		t.Error("Unfold")
+	}
+}
+
+func TestDeconstruct(t *testing.T) {
+	if Head[int](naturals) != maybe.Just(0) {
+		t.Error("Head")
+	}
+
+	if Head[int](Nil[int]()) != maybe.Nothing[int]() {
+		t.Error("Head of Nil")
+	}
+
+	if Head[int](Tail[int](naturals)) != maybe.Just(1) {
+		t.Error("Tail")
+	}
+
+	if Take[int](0, naturals) != list.Nil[int]() {
+		t.Error("Take none")
+	}
+
+	if Take[int](5, Singleton(1)) != list.Singleton(1) {
+		t.Error("Take more than available")
+	}
+
+	if Head[int](Drop[int](1000000, naturals)) != maybe.Just(1000000) {
+		t.Error("Drop")
+	}
+}
+
+func TestTransform(t *testing.T) {
+	double := func(x int) int { return x * 2 }
+	isOdd := func(x int) bool { return x%2 == 1 }
+
+	if Take[int](3, Map(double, naturals)) != list.FromSlice([]int{0, 2, 4}) {
+		t.Error("Map")
+	}
+
+	if Take[int](3, Filter(isOdd, naturals)) != list.FromSlice([]int{1, 3, 5}) {
+		t.Error("Filter")
+	}
+
+	if ToList[int](TakeWhile(func(x int) bool { return x < 4 }, naturals)) != list.Range(0, 3) {
+		t.Error("TakeWhile")
+	}
+
+	if Head[int](DropWhile(func(x int) bool { return x < 4 }, naturals)) != maybe.Just(4) {
+		t.Error("DropWhile")
+	}
+
+	if Take[tuple.Tuple[int, string]](2, Zip[int, string](naturals, Repeat("x"))) != list.FromSlice([]tuple.Tuple[int, string]{tuple.Pair(0, "x"), tuple.Pair(1, "x")}) {
+		t.Error("Zip")
+	}
+
+	if ToList[tuple.Tuple[int, int]](Zip[int, int](naturals, FromList[int](list.Range(1, 2)))) != list.FromSlice([]tuple.Tuple[int, int]{tuple.Pair(0, 1), tuple.Pair(1, 2)}) {
+		t.Error("Zip ends with the shorter stream")
+	}
+}
+
+func TestLaziness(t *testing.T) {
+	calls := 0
+	counted := Map(func(x int) int {
+		calls++
+		return x
+	}, naturals)
+
+	Take[int](3, counted)
+	Take[int](3, counted)
+
+	if calls != 3 {
+		t.Errorf("Tails are memoized, got %d calls", calls)
+	}
+
+	if counted.String() != "[0, 1, 2, ...]" {
+		t.Errorf("String shows the evaluated prefix, got %s", counted.String())
+	}
+
+	if Singleton(1).String() != "[1, ...]" || ToList[int](Singleton(1)) != list.Singleton(1) {
+		t.Error("String of a singleton")
+	}
+}
@@ -1,8 +1,11 @@
 package tuple
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/obiloud/curry-go/nub"
 	"github.com/obiloud/curry-go/util"
 )
 
@@ -22,6 +25,27 @@ func (pair Tuple[A, B]) String() string {
 	return fmt.Sprintf("(%s, %s)", util.Stringify(pair.first), util.Stringify(pair.second))
 }
 
+func (pair Tuple[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{pair.first, pair.second})
+}
+
+func (pair *Tuple[A, B]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if len(elems) != 2 {
+		return errors.New("tuple: expected an array of 2 elements")
+	}
+	if err := json.Unmarshal(elems[0], &pair.first); err != nil {
+		return err
+	}
+	return json.Unmarshal(elems[1], &pair.second)
+}
+
 func First[A, B any](tuple Tuple[A, B]) A {
 	return tuple.first
 }
@@ -48,3 +72,66 @@ func MapBoth[A, B, C, D any](f1 func(A) C, f2 func(B) D, pair Tuple[A, B]) Tuple
 
 	return Pair(first, second)
 }
+
+type Triple[A, B, C any] struct {
+	first  A
+	second B
+	third  C
+}
+
+func Trio[A, B, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{
+		first:  first,
+		second: second,
+		third:  third,
+	}
+}
+
+func (triple Triple[A, B, C]) String() string {
+	return fmt.Sprintf("(%s, %s, %s)", util.Stringify(triple.first), util.Stringify(triple.second), util.Stringify(triple.third))
+}
+
+func (triple Triple[A, B, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{triple.first, triple.second, triple.third})
+}
+
+func (triple *Triple[A, B, C]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if len(elems) != 3 {
+		return errors.New("tuple: expected an array of 3 elements")
+	}
+	if err := json.Unmarshal(elems[0], &triple.first); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(elems[1], &triple.second); err != nil {
+		return err
+	}
+	return json.Unmarshal(elems[2], &triple.third)
+}
+
+func First3[A, B, C any](triple Triple[A, B, C]) A {
+	return triple.first
+}
+
+func Second3[A, B, C any](triple Triple[A, B, C]) B {
+	return triple.second
+}
+
+func Third3[A, B, C any](triple Triple[A, B, C]) C {
+	return triple.third
+}
+
+func Lexicographic[A, B any](c1 nub.Comparator[A], c2 nub.Comparator[B]) nub.Comparator[Tuple[A, B]] {
+	return func(x Tuple[A, B], y Tuple[A, B]) nub.Order {
+		if o := c1(x.first, y.first); o != nub.EQ {
+			return o
+		}
+		return c2(x.second, y.second)
+	}
+}
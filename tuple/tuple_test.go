@@ -0,0 +1,75 @@
+package tuple
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/obiloud/curry-go/nub"
+)
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(Pair("a", 1))
+	if err != nil || string(data) != `["a",1]` {
+		t.Errorf("Tuple encodes as a 2-element array, got %s", data)
+	}
+
+	var pair Tuple[string, int]
+	if err := json.Unmarshal(data, &pair); err != nil || pair != Pair("a", 1) {
+		t.Error("Tuple round trip")
+	}
+
+	if err := json.Unmarshal([]byte(`["a",1,2]`), &pair); err == nil {
+		t.Error("Tuple from a 3-element array")
+	}
+
+	if err := json.Unmarshal([]byte(`[1,1]`), &pair); err == nil {
+		t.Error("Tuple with the wrong element type")
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &pair); err != nil || pair != Pair("a", 1) {
+		t.Error("null leaves the tuple unchanged")
+	}
+
+	triple := Trio("a", 1, true)
+	if err := json.Unmarshal([]byte(`null`), &triple); err != nil || triple != Trio("a", 1, true) {
+		t.Error("null leaves the triple unchanged")
+	}
+}
+
+func TestTriple(t *testing.T) {
+	triple := Trio("a", 1, true)
+
+	if First3(triple) != "a" || Second3(triple) != 1 || Third3(triple) != true {
+		t.Error("Triple accessors")
+	}
+
+	if triple.String() != `("a", 1, true)` {
+		t.Errorf("Triple string, got %s", triple.String())
+	}
+
+	data, err := json.Marshal(triple)
+	if err != nil || string(data) != `["a",1,true]` {
+		t.Errorf("Triple encodes as a 3-element array, got %s", data)
+	}
+
+	var decoded Triple[string, int, bool]
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != triple {
+		t.Error("Triple round trip")
+	}
+
+	if err := json.Unmarshal([]byte(`["a",1]`), &decoded); err == nil {
+		t.Error("Triple from a 2-element array")
+	}
+}
+
+func TestLexicographic(t *testing.T) {
+	lex := Lexicographic(nub.Natural[string](), nub.Reverse(nub.Natural[int]()))
+
+	if lex(Pair("a", 1), Pair("b", 0)) != nub.LT {
+		t.Error("first component decides")
+	}
+
+	if lex(Pair("a", 1), Pair("a", 2)) != nub.GT || lex(Pair("a", 1), Pair("a", 1)) != nub.EQ {
+		t.Error("second component breaks ties")
+	}
+}
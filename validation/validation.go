@@ -0,0 +1,253 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/obiloud/curry-go/either"
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+	"github.com/obiloud/curry-go/util"
+)
+
+// The result of a check that either succeeded with a value or failed with one
+// or more errors. Unlike Either, combining validations keeps every failure
+// instead of stopping at the first one.
+type Validation[E, A any] interface {
+	IsValid() bool
+	IsInvalid() bool
+	String() string
+	failures() list.List[E]
+	value() A
+}
+
+type failure[E, A any] struct {
+	errs list.List[E]
+}
+
+type success[E, A any] struct {
+	obj A
+}
+
+func (f failure[E, A]) IsValid() bool {
+	return false
+}
+
+func (f failure[E, A]) IsInvalid() bool {
+	return true
+}
+
+func (f failure[E, A]) String() string {
+	return fmt.Sprintf("Failure(%s)", f.errs.String())
+}
+
+func (f failure[E, A]) failures() list.List[E] {
+	return f.errs
+}
+
+func (f failure[E, A]) value() A {
+	return *new(A)
+}
+
+func (s success[E, A]) IsValid() bool {
+	return true
+}
+
+func (s success[E, A]) IsInvalid() bool {
+	return false
+}
+
+func (s success[E, A]) String() string {
+	return fmt.Sprintf("Success(%s)", util.Stringify(s.obj))
+}
+
+func (s success[E, A]) failures() list.List[E] {
+	return list.Nil[E]()
+}
+
+func (s success[E, A]) value() A {
+	return s.obj
+}
+
+// CREATE
+
+// A successful validation.
+func Succeed[E, A any](x A) Validation[E, A] {
+	return success[E, A]{obj: x}
+}
+
+// A validation that failed with a single error.
+func Fail[E, A any](err E) Validation[E, A] {
+	return failure[E, A]{errs: list.Singleton(err)}
+}
+
+// A validation that failed with several errors. An empty list of errors still
+// counts as a failure.
+func FailMany[E, A any](errs list.List[E]) Validation[E, A] {
+	return failure[E, A]{errs: errs}
+}
+
+// DECONSTRUCT
+
+// Get the value of a successful validation, or the given default.
+func WithDefault[E, A any](x A, v Validation[E, A]) A {
+	if s, ok := v.(success[E, A]); ok {
+		return s.obj
+	}
+	return x
+}
+
+// Get every error of a failed validation, in the order they were found.
+func Errors[E, A any](v Validation[E, A]) list.List[E] {
+	return v.failures()
+}
+
+// TRANSFORM
+
+// Apply a function to the value of a successful validation.
+func Map[E, A, B any](fn func(A) B, v Validation[E, A]) Validation[E, B] {
+	if s, ok := v.(success[E, A]); ok {
+		return Succeed[E](fn(s.obj))
+	}
+	return FailMany[E, B](v.failures())
+}
+
+// Apply a function to every error of a failed validation.
+func MapError[E, F, A any](fn func(E) F, v Validation[E, A]) Validation[F, A] {
+	if s, ok := v.(success[E, A]); ok {
+		return Succeed[F](s.obj)
+	}
+	return FailMany[F, A](list.Map(fn, v.failures()))
+}
+
+type failing[E any] interface {
+	failures() list.List[E]
+}
+
+// Collect the errors of several validations, in argument order.
+func collect[E, A any](vs ...failing[E]) Validation[E, A] {
+	errs := make([]list.List[E], len(vs))
+	for i, v := range vs {
+		errs[i] = v.failures()
+	}
+	return FailMany[E, A](list.Concat[E](list.FromSlice(errs)))
+}
+
+// Combine two validations. If any of them failed, the errors of all of them
+// are kept.
+func Map2[E, A, B, R any](fn func(A, B) R, v1 Validation[E, A], v2 Validation[E, B]) Validation[E, R] {
+	if v1.IsValid() && v2.IsValid() {
+		return Succeed[E](fn(v1.value(), v2.value()))
+	}
+	return collect[E, R](v1, v2)
+}
+
+func Map3[E, A, B, C, R any](fn func(A, B, C) R, v1 Validation[E, A], v2 Validation[E, B], v3 Validation[E, C]) Validation[E, R] {
+	if v1.IsValid() && v2.IsValid() && v3.IsValid() {
+		return Succeed[E](fn(v1.value(), v2.value(), v3.value()))
+	}
+	return collect[E, R](v1, v2, v3)
+}
+
+func Map4[E, A, B, C, D, R any](fn func(A, B, C, D) R, v1 Validation[E, A], v2 Validation[E, B], v3 Validation[E, C], v4 Validation[E, D]) Validation[E, R] {
+	if v1.IsValid() && v2.IsValid() && v3.IsValid() && v4.IsValid() {
+		return Succeed[E](fn(v1.value(), v2.value(), v3.value(), v4.value()))
+	}
+	return collect[E, R](v1, v2, v3, v4)
+}
+
+func Map5[E, A, B, C, D, F, R any](fn func(A, B, C, D, F) R, v1 Validation[E, A], v2 Validation[E, B], v3 Validation[E, C], v4 Validation[E, D], v5 Validation[E, F]) Validation[E, R] {
+	if v1.IsValid() && v2.IsValid() && v3.IsValid() && v4.IsValid() && v5.IsValid() {
+		return Succeed[E](fn(v1.value(), v2.value(), v3.value(), v4.value(), v5.value()))
+	}
+	return collect[E, R](v1, v2, v3, v4, v5)
+}
+
+func Map6[E, A, B, C, D, F, G, R any](fn func(A, B, C, D, F, G) R, v1 Validation[E, A], v2 Validation[E, B], v3 Validation[E, C], v4 Validation[E, D], v5 Validation[E, F], v6 Validation[E, G]) Validation[E, R] {
+	if v1.IsValid() && v2.IsValid() && v3.IsValid() && v4.IsValid() && v5.IsValid() && v6.IsValid() {
+		return Succeed[E](fn(v1.value(), v2.value(), v3.value(), v4.value(), v5.value(), v6.value()))
+	}
+	return collect[E, R](v1, v2, v3, v4, v5, v6)
+}
+
+func Map7[E, A, B, C, D, F, G, H, R any](fn func(A, B, C, D, F, G, H) R, v1 Validation[E, A], v2 Validation[E, B], v3 Validation[E, C], v4 Validation[E, D], v5 Validation[E, F], v6 Validation[E, G], v7 Validation[E, H]) Validation[E, R] {
+	if v1.IsValid() && v2.IsValid() && v3.IsValid() && v4.IsValid() && v5.IsValid() && v6.IsValid() && v7.IsValid() {
+		return Succeed[E](fn(v1.value(), v2.value(), v3.value(), v4.value(), v5.value(), v6.value(), v7.value()))
+	}
+	return collect[E, R](v1, v2, v3, v4, v5, v6, v7)
+}
+
+func Map8[E, A, B, C, D, F, G, H, I, R any](fn func(A, B, C, D, F, G, H, I) R, v1 Validation[E, A], v2 Validation[E, B], v3 Validation[E, C], v4 Validation[E, D], v5 Validation[E, F], v6 Validation[E, G], v7 Validation[E, H], v8 Validation[E, I]) Validation[E, R] {
+	if v1.IsValid() && v2.IsValid() && v3.IsValid() && v4.IsValid() && v5.IsValid() && v6.IsValid() && v7.IsValid() && v8.IsValid() {
+		return Succeed[E](fn(v1.value(), v2.value(), v3.value(), v4.value(), v5.value(), v6.value(), v7.value(), v8.value()))
+	}
+	return collect[E, R](v1, v2, v3, v4, v5, v6, v7, v8)
+}
+
+// Apply a validated function to a validated value. Errors of the function come
+// before errors of the value.
+func Apply[E, A, B any](vf Validation[E, func(A) B], va Validation[E, A]) Validation[E, B] {
+	return Map2(func(fn func(A) B, x A) B {
+		return fn(x)
+	}, vf, va)
+}
+
+// LISTS
+
+// Validate every element of a list, collecting all errors. Succeeds with the
+// list of results only when every element is valid.
+func Traverse[E, A, B any](fn func(A) Validation[E, B], ls list.List[A]) Validation[E, list.List[B]] {
+	return list.FoldR(func(x A, acc Validation[E, list.List[B]]) Validation[E, list.List[B]] {
+		return Map2(list.Cons[B], fn(x), acc)
+	}, Succeed[E](list.Nil[B]()), ls)
+}
+
+// Turn a list of validations into a validation of a list, collecting all errors.
+func Sequence[E, A any](ls list.List[Validation[E, A]]) Validation[E, list.List[A]] {
+	return Traverse(func(v Validation[E, A]) Validation[E, A] { return v }, ls)
+}
+
+// EITHER
+
+// Convert an Either into a validation. A Left becomes a single error.
+func FromEither[E, A any](e either.Either[E, A]) Validation[E, A] {
+	return either.Match(e, Fail[E, A], Succeed[E, A])
+}
+
+// Convert a validation into an Either carrying every error.
+func ToEither[E, A any](v Validation[E, A]) either.Either[list.List[E], A] {
+	if s, ok := v.(success[E, A]); ok {
+		return either.FromRight[list.List[E]](s.obj)
+	}
+	return either.FromLeft[list.List[E], A](v.failures())
+}
+
+// Convert a validation into an optional value, dropping the errors.
+func ToMaybe[E, A any](v Validation[E, A]) maybe.Maybe[A] {
+	if s, ok := v.(success[E, A]); ok {
+		return maybe.Just(s.obj)
+	}
+	return maybe.Nothing[A]()
+}
+
+// GO errors
+
+// Convert the result of a Go function call into a validation.
+func FromResult[A any](val A, err error) Validation[error, A] {
+	if err != nil {
+		return Fail[error, A](err)
+	}
+	return Succeed[error](val)
+}
+
+// Convert a validation into Go's value and error convention. All errors are
+// combined with errors.Join, so errors.Is and errors.As see each of them.
+func ToResult[A any](v Validation[error, A]) (A, error) {
+	if s, ok := v.(success[error, A]); ok {
+		return s.obj, nil
+	}
+	if err := errors.Join(list.ToSlice[error](v.failures())...); err != nil {
+		return *new(A), err
+	}
+	return *new(A), errors.New("validation failed")
+}
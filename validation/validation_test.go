@@ -0,0 +1,174 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/obiloud/curry-go/either"
+	"github.com/obiloud/curry-go/list"
+	"github.com/obiloud/curry-go/maybe"
+)
+
+type user struct {
+	name string
+	age  int
+}
+
+func validName(name string) Validation[string, string] {
+	if name == "" {
+		return Fail[string, string]("name is empty")
+	}
+	return Succeed[string](name)
+}
+
+func validAge(age int) Validation[string, int] {
+	if age < 0 {
+		return Fail[string, int]("age is negative")
+	}
+	return Succeed[string](age)
+}
+
+func newUser(name string, age int) user {
+	return user{name: name, age: age}
+}
+
+func TestMap(t *testing.T) {
+	add1 := func(x int) int {
+		return x + 1
+	}
+	if Map(add1, Succeed[string](1)) != Succeed[string](2) {
+		t.Error("map Success")
+	}
+	if Errors(Map(add1, Fail[string, int]("x"))) != list.Singleton("x") {
+		t.Error("map Failure")
+	}
+	if Errors(MapError(strconv.Itoa, Fail[int, string](1))) != list.Singleton("1") {
+		t.Error("mapError Failure")
+	}
+}
+
+func TestMap2(t *testing.T) {
+	if Map2(newUser, validName("Tom"), validAge(3)) != Succeed[string](user{name: "Tom", age: 3}) {
+		t.Error("map2 Success")
+	}
+
+	if Errors(Map2(newUser, validName("Tom"), validAge(-1))) != list.Singleton("age is negative") {
+		t.Error("map2 one Failure")
+	}
+
+	if Errors(Map2(newUser, validName(""), validAge(-1))) != list.FromSlice([]string{"name is empty", "age is negative"}) {
+		t.Error("map2 collects every Failure")
+	}
+}
+
+func TestMapN(t *testing.T) {
+	ok := Succeed[string](1)
+	bad := func(msg string) Validation[string, int] {
+		return Fail[string, int](msg)
+	}
+
+	sum8 := func(a, b, c, d, e, f, g, h int) int {
+		return a + b + c + d + e + f + g + h
+	}
+
+	if Map8(sum8, ok, ok, ok, ok, ok, ok, ok, ok) != Succeed[string](8) {
+		t.Error("map8 Success")
+	}
+
+	if Errors(Map8(sum8, bad("1"), ok, bad("3"), ok, ok, ok, ok, bad("8"))) != list.FromSlice([]string{"1", "3", "8"}) {
+		t.Error("map8 collects every Failure in order")
+	}
+
+	sum3 := func(a, b, c int) int {
+		return a + b + c
+	}
+
+	if Map3(sum3, ok, ok, ok) != Succeed[string](3) {
+		t.Error("map3 Success")
+	}
+
+	if Errors(Map5(func(a, b, c, d, e int) int { return 0 }, ok, bad("2"), ok, bad("4"), ok)) != list.FromSlice([]string{"2", "4"}) {
+		t.Error("map5 Failure")
+	}
+}
+
+func TestApply(t *testing.T) {
+	add1 := Succeed[string](func(x int) int { return x + 1 })
+
+	if Apply(add1, Succeed[string](1)) != Succeed[string](2) {
+		t.Error("apply Success")
+	}
+
+	failed := Fail[string, func(int) int]("no function")
+
+	if Errors(Apply(failed, Fail[string, int]("no value"))) != list.FromSlice([]string{"no function", "no value"}) {
+		t.Error("apply collects every Failure")
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	if Traverse(validAge, list.Range(1, 3)) != Succeed[string](list.Range(1, 3)) {
+		t.Error("traverse Success")
+	}
+
+	if Errors(Traverse(validAge, list.FromSlice([]int{1, -1, 2, -2}))) != list.Repeat(2, "age is negative") {
+		t.Error("traverse collects every Failure")
+	}
+
+	if Sequence[string, int](list.FromSlice([]Validation[string, int]{Succeed[string](1), Succeed[string](2)})) != Succeed[string](list.Range(1, 2)) {
+		t.Error("sequence Success")
+	}
+
+	if Errors(Sequence[string, int](list.FromSlice([]Validation[string, int]{Fail[string, int]("a"), Succeed[string](2), Fail[string, int]("b")}))) != list.FromSlice([]string{"a", "b"}) {
+		t.Error("sequence Failure")
+	}
+}
+
+func TestEither(t *testing.T) {
+	if FromEither[string, int](either.FromRight[string](1)) != Succeed[string](1) {
+		t.Error("from Right")
+	}
+
+	if Errors(FromEither[string, int](either.FromLeft[string, int]("x"))) != list.Singleton("x") {
+		t.Error("from Left")
+	}
+
+	if ToEither(Succeed[string](1)) != either.FromRight[list.List[string]](1) {
+		t.Error("to Right")
+	}
+
+	if ToEither(Map2(newUser, validName(""), validAge(-1))) != either.FromLeft[list.List[string], user](list.FromSlice([]string{"name is empty", "age is negative"})) {
+		t.Error("to Left")
+	}
+
+	if ToMaybe(Succeed[string](1)) != maybe.Just(1) || ToMaybe(Fail[string, int]("x")) != maybe.Nothing[int]() {
+		t.Error("to Maybe")
+	}
+
+	if WithDefault(0, Succeed[string](1)) != 1 || WithDefault(0, Fail[string, int]("x")) != 0 {
+		t.Error("with default")
+	}
+}
+
+var errMissing = errors.New("missing")
+
+func TestResult(t *testing.T) {
+	if x, err := ToResult(FromResult(strconv.Atoi("1"))); x != 1 || err != nil {
+		t.Error("to result Success")
+	}
+
+	port := FromResult(strconv.Atoi("x"))
+	host := Fail[error, string](fmt.Errorf("host: %w", errMissing))
+
+	_, err := ToResult(Map2(func(p int, h string) string { return h + ":" + strconv.Itoa(p) }, port, host))
+
+	if !errors.Is(err, strconv.ErrSyntax) || !errors.Is(err, errMissing) {
+		t.Error("to result joins every error")
+	}
+
+	if _, err := ToResult(FailMany[error, int](list.Nil[error]())); err == nil {
+		t.Error("to result of a Failure without errors")
+	}
+}